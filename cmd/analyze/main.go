@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/jakeChal/gotune/pkg/audio/source"
+	"github.com/jakeChal/gotune/pkg/config"
+	"github.com/jakeChal/gotune/pkg/dsp"
+)
+
+// bufferSize mirrors source.AnalyzeFile's own internal analysis window
+// size, so sampleIndex stays in step with the windows it actually emits.
+const bufferSize = 4096
+
+// pitchRow is one PitchTracker event, timestamped by the sample offset of
+// the analysis window it was computed from.
+type pitchRow struct {
+	SampleIndex int     `json:"sample_index"`
+	Frequency   float64 `json:"frequency"`
+	Note        string  `json:"note"`
+	Cents       float64 `json:"cents"`
+	Clarity     float64 `json:"clarity"`
+	HasPitch    bool    `json:"has_pitch"`
+}
+
+func main() {
+	filename := flag.String("f", "", "Recording to analyze -- any format registered with pkg/audio/source (required)")
+	format := flag.String("format", "json", "Output format: json or csv")
+	instrumentName := flag.String("i", "guitar", "Instrument profile (guitar, bouzouki).")
+	flag.Parse()
+
+	if *filename == "" {
+		fmt.Fprintln(os.Stderr, "usage: analyze -f <file.wav> [-format json|csv] [-i guitar]")
+		os.Exit(1)
+	}
+
+	profile, ok := config.Profiles[*instrumentName]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown instrument: %s\n", *instrumentName)
+		fmt.Fprintf(os.Stderr, "Supported instruments: %v\n", config.ListInstruments())
+		os.Exit(1)
+	}
+
+	rows, err := analyze(*filename, profile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := writeResults(os.Stdout, *format, rows); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// analyze replays a recorded file -- any format registered with
+// pkg/audio/source, not just WAV -- through the same PitchTracker used
+// for live capture, so a take can be regression-tested deterministically.
+func analyze(filename string, profile config.InstrumentProfile) ([]pitchRow, error) {
+	results, err := source.AnalyzeFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []pitchRow
+	sampleIndex := 0
+	for result := range results {
+		row := pitchRow{
+			SampleIndex: sampleIndex,
+			Frequency:   result.Frequency,
+			Clarity:     result.Clarity,
+			HasPitch:    result.HasPitch,
+		}
+		if result.HasPitch && result.Frequency >= profile.MinFreq && result.Frequency <= profile.MaxFreq {
+			row.Note, _, row.Cents = dsp.PitchToNote(result.Frequency)
+		}
+		rows = append(rows, row)
+		sampleIndex += bufferSize
+	}
+
+	return rows, nil
+}
+
+func writeResults(w io.Writer, format string, rows []pitchRow) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	case "csv":
+		cw := csv.NewWriter(w)
+		defer cw.Flush()
+		if err := cw.Write([]string{"sample_index", "frequency", "note", "cents", "clarity", "has_pitch"}); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			record := []string{
+				strconv.Itoa(row.SampleIndex),
+				strconv.FormatFloat(row.Frequency, 'f', 3, 64),
+				row.Note,
+				strconv.FormatFloat(row.Cents, 'f', 2, 64),
+				strconv.FormatFloat(row.Clarity, 'f', 4, 64),
+				strconv.FormatBool(row.HasPitch),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("analyze: unknown format %q", format)
+	}
+}
@@ -7,20 +7,46 @@ import (
 	"os/signal"
 	"syscall"
 
-	"gitlab.com/jacobidis/gotune/pkg/audio"
+	"github.com/jakeChal/gotune/pkg/audio"
 )
 
 func main() {
-	filename := flag.String("f", "audio.wav", "Filename of recording")
+	dir := flag.String("dir", ".", "Directory recordings are written into")
+	prefix := flag.String("prefix", "capture", "Filename prefix for rolling recordings")
+	format := flag.String("format", "int16", "Sample format: int16 or float32")
+	maxBytes := flag.Int64("max-bytes", 0, "Roll to a new file after this many bytes (0 disables)")
+	maxDuration := flag.Duration("max-duration", 0, "Roll to a new file after this long (0 disables)")
+	flac := flag.Bool("flac", false, "Record FLAC instead of WAV (requires -tags enable_codec_libflac)")
 	flag.Parse()
+
 	sampleRate := uint32(48000)
 	numChannels := uint32(1)
-	writer, err := audio.NewWriter(*filename, sampleRate, numChannels)
+
+	sampleFormat := audio.SampleFormatInt16
+	if *format == "float32" {
+		sampleFormat = audio.SampleFormatFloat32
+	}
+
+	container := audio.ContainerWAV
+	if *flac {
+		container = audio.ContainerFLAC
+	}
+
+	recorder, err := audio.NewRecorder(audio.RecorderConfig{
+		Dir:         *dir,
+		Prefix:      *prefix,
+		SampleRate:  sampleRate,
+		Channels:    numChannels,
+		Format:      sampleFormat,
+		Container:   container,
+		MaxBytes:    *maxBytes,
+		MaxDuration: *maxDuration,
+	})
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
-	defer writer.Close()
+	defer recorder.Close()
 
 	ai, err := audio.NewAudioInput(sampleRate)
 	if err != nil {
@@ -46,7 +72,7 @@ func main() {
 	}()
 
 	for frame := range ai.Frames {
-		if err := writer.WriteFrame(frame); err != nil {
+		if err := recorder.WriteFrame(frame); err != nil {
 			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
 		}
@@ -3,21 +3,34 @@ package main
 import (
 	"flag"
 	"fmt"
+	"math"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/jakeChal/gotune/pkg/audio"
 	"github.com/jakeChal/gotune/pkg/config"
 	"github.com/jakeChal/gotune/pkg/dsp"
+	"github.com/jakeChal/gotune/pkg/dsp/filter"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 const (
-	sampleRate        = 48000
-	bufferSize        = 4096
-	silenceThreshold  = 0.001
-	minDisplayClarity = 0.3
+	sampleRate         = 48000
+	bufferSize         = 4096
+	detectionThreshold = 0.1
+	silenceThreshold   = 0.001
+	minDisplayClarity  = 0.3
+	referenceHarmonics = 2 // overtones mixed into the reference tone for a pluck-like timbre
+
+	// analysisSampleRate is what DetectPitch actually runs against: the
+	// filter chain resamples captured audio down to it before windowing,
+	// halving NSDF cost relative to analysing at the full capture rate.
+	analysisSampleRate = sampleRate / 2
+	analysisBufferSize = bufferSize / 2
+	resamplerTaps      = 16
 )
 
 var (
@@ -37,20 +50,27 @@ var (
 
 // current state
 type model struct {
-	frequency float64
-	noteName  string
-	cents     float64
-	hasPitch  bool
-	profile   config.InstrumentProfile
-	audioChan chan pitchMsg
+	frequency     float64
+	noteName      string
+	cents         float64
+	hasPitch      bool
+	matchedString string // e.g. "6th String (E2)"; empty when no string is in range
+	profile       config.InstrumentProfile
+	tuning        dsp.Tuning
+	audioChan     chan pitchMsg
+
+	player         *audio.Player // reference-tone playback; nil if unavailable
+	playingIndex   int           // index into profile.Strings currently sounding, or -1
+	referenceCents float64       // ear-training offset applied on top of the playing string's target
 }
 
 // event carrying new data (to be copied into model)
 type pitchMsg struct {
-	frequency float64
-	noteName  string
-	cents     float64
-	hasPitch  bool
+	frequency     float64
+	noteName      string
+	cents         float64
+	hasPitch      bool
+	matchedString string
 }
 
 func listenForAudio(audioChan chan pitchMsg) tea.Cmd {
@@ -59,14 +79,17 @@ func listenForAudio(audioChan chan pitchMsg) tea.Cmd {
 	}
 }
 
-func initialModel(profile config.InstrumentProfile, audioChan chan pitchMsg) model {
+func initialModel(profile config.InstrumentProfile, tuning dsp.Tuning, audioChan chan pitchMsg, player *audio.Player) model {
 	return model{
-		profile:   profile,
-		audioChan: audioChan,
+		profile:      profile,
+		tuning:       tuning,
+		audioChan:    audioChan,
+		player:       player,
+		playingIndex: -1,
 	}
 }
 
-func renderHeader(profile config.InstrumentProfile) string {
+func renderHeader(profile config.InstrumentProfile, tuning dsp.Tuning, matchedString string) string {
 	headerStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		Padding(0, 1).
@@ -75,12 +98,18 @@ func renderHeader(profile config.InstrumentProfile) string {
 
 	text := fmt.Sprintf("%s Tuner\n%.0f - %.0f Hz",
 		profile.Name, profile.MinFreq, profile.MaxFreq)
+	if matchedString != "" {
+		text = fmt.Sprintf("%s Tuner\n%s", profile.Name, matchedString)
+	}
+	if tuning.ReferenceHz != dsp.StandardTuning.ReferenceHz {
+		text += fmt.Sprintf("\nA4 = %.0f Hz", tuning.ReferenceHz)
+	}
 
 	return headerStyle.Render(text)
 }
 
 func renderFooter() string {
-	return helpStyle.Render("q: quit  •  ctrl+c: exit")
+	return helpStyle.Render("q: quit  •  ctrl+c: exit  •  1-6: play string  •  ↑/↓: ±1¢")
 }
 
 func (m model) Init() tea.Cmd {
@@ -88,7 +117,7 @@ func (m model) Init() tea.Cmd {
 }
 
 func (m model) View() string {
-	header := renderHeader(m.profile)
+	header := renderHeader(m.profile, m.tuning, m.matchedString)
 
 	var body string
 	if !m.hasPitch {
@@ -107,55 +136,126 @@ func (m model) View() string {
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if msg.String() == "q" || msg.String() == "ctrl+c" {
+		switch msg.String() {
+		case "q", "ctrl+c":
 			return m, tea.Quit
+		case "1", "2", "3", "4", "5", "6":
+			m = m.toggleReferenceString(msg.String())
+		case "up":
+			m = m.nudgeReferenceTone(1)
+		case "down":
+			m = m.nudgeReferenceTone(-1)
 		}
 	case pitchMsg:
 		m.frequency = msg.frequency
 		m.noteName = msg.noteName
 		m.cents = msg.cents
 		m.hasPitch = msg.hasPitch
+		m.matchedString = msg.matchedString
 		return m, listenForAudio(m.audioChan)
 	}
 
 	return m, nil
 }
 
-func processAudio(ai *audio.AudioInput, audioChan chan pitchMsg, profile config.InstrumentProfile) {
-	threshold := 0.1
-	bufferAccum := make([]float64, 0, bufferSize)
+// toggleReferenceString starts the reference tone for the string at the
+// pressed digit's position (1-indexed, matching profile.Strings order),
+// or silences it if that string is already sounding.
+func (m model) toggleReferenceString(key string) model {
+	if m.player == nil || len(m.profile.Strings) == 0 {
+		return m
+	}
 
-	for frame := range ai.Frames {
-		frame64 := dsp.Float32ToFloat64(frame)
-		bufferAccum = append(bufferAccum, frame64...)
-
-		if len(bufferAccum) >= bufferSize {
-			energy := dsp.CalculateRMS(bufferAccum[:bufferSize])
-			if energy < silenceThreshold {
-				bufferAccum = bufferAccum[bufferSize:]
-				continue
-			}
+	idx, _ := strconv.Atoi(key)
+	idx--
+	if idx < 0 || idx >= len(m.profile.Strings) {
+		return m
+	}
 
-			result := dsp.DetectPitch(bufferAccum[:bufferSize], sampleRate, threshold)
+	if m.playingIndex == idx {
+		m.player.Stop()
+		m.playingIndex = -1
+		return m
+	}
+
+	m.playingIndex = idx
+	m.referenceCents = 0
+	m.player.Play(m.profile.Strings[idx].TargetHz, referenceHarmonics)
+	return m
+}
+
+// nudgeReferenceTone detunes the currently-sounding reference tone by
+// deltaCents, for ear training against a target that isn't dead-on pitch.
+func (m model) nudgeReferenceTone(deltaCents float64) model {
+	if m.player == nil || m.playingIndex < 0 {
+		return m
+	}
 
-			// display
+	m.referenceCents += deltaCents
+	freq := m.profile.Strings[m.playingIndex].TargetHz * math.Pow(2, m.referenceCents/1200)
+	m.player.Play(freq, referenceHarmonics)
+	return m
+}
+
+// buildFilterChain assembles the DSP pipeline a captured window passes
+// through before pitch detection: DC blocking, a Butterworth bandpass
+// constrained to the profile's range (so bouzouki's 110-360 Hz gets a
+// much tighter filter than guitar's 75-1400 Hz), a polyphase resample
+// down to analysisSampleRate (so DetectPitch runs its NSDF over half as
+// many samples), a Hann window tapering the resampled analysis buffer
+// ahead of that NSDF, and the RMS gate that replaces the old hard
+// silenceThreshold check.
+func buildFilterChain(profile config.InstrumentProfile) *filter.Chain {
+	return filter.NewChain(
+		filter.NewDCBlocker(),
+		filter.NewBandpass(profile.MinFreq, profile.MaxFreq, float64(sampleRate)),
+		filter.NewResampler(sampleRate, analysisSampleRate, resamplerTaps),
+		filter.NewHannWindow(analysisBufferSize),
+		filter.NewRMSGate(silenceThreshold),
+	)
+}
+
+func processAudio(ai *audio.AudioInput, audioChan chan pitchMsg, profile config.InstrumentProfile, tuning dsp.Tuning, player *audio.Player) {
+	// The RMS gate in the filter chain now does the job silenceThreshold
+	// used to do inline, so the tracker's own silence check is disabled.
+	// bufferSize is the raw, pre-resample accumulation threshold;
+	// analysisSampleRate is the rate DetectPitch actually sees once the
+	// filter chain has resampled the window down.
+	tracker := dsp.NewPitchTracker(analysisSampleRate, bufferSize, detectionThreshold, 0)
+	tracker.SetFilter(buildFilterChain(profile))
+
+	for frame := range ai.Frames {
+		// While the reference tone is sounding, skip analysis entirely
+		// rather than feed the speaker output back into DetectPitch.
+		if player != nil && player.IsPlaying() {
+			continue
+		}
+
+		for _, result := range tracker.Push(dsp.Float32ToFloat64(frame)) {
 			if result.HasPitch &&
 				result.Clarity >= minDisplayClarity &&
 				result.Frequency >= profile.MinFreq &&
 				result.Frequency <= profile.MaxFreq {
-				noteName, _, centsOff := dsp.PitchToNote(result.Frequency)
+				noteName, _, cents := tuning.PitchToNote(result.Frequency)
+
+				// Snap to the intended string, if one is configured and
+				// in range, rather than the nearest chromatic note: this
+				// is what keeps a slack low E from reading as "in tune"
+				// once it's been cranked up to F2.
+				matchedString := ""
+				if spec, centsOff := profile.MatchString(result.Frequency); spec.Name != "" {
+					matchedString = spec.Name
+					cents = centsOff
+				}
 
-				newData := pitchMsg{
-					frequency: result.Frequency,
-					noteName:  noteName,
-					cents:     centsOff,
-					hasPitch:  true,
+				audioChan <- pitchMsg{
+					frequency:     result.Frequency,
+					noteName:      noteName,
+					cents:         cents,
+					hasPitch:      true,
+					matchedString: matchedString,
 				}
-				audioChan <- newData
 			}
-			// Keep overflow samples for better continuity
-			bufferAccum = bufferAccum[bufferSize:]
-
 		}
 	}
 }
@@ -248,9 +348,59 @@ func tuningStatus(cents float64) string {
 	}
 }
 
+// parseTemperamentFlag parses a -temperament flag value of the form
+// "name[:param]", e.g. "just:D" or "scala:/path/to/scale.scl".
+func parseTemperamentFlag(value string) (dsp.Temperament, error) {
+	name, param, _ := strings.Cut(value, ":")
+
+	switch name {
+	case "", "equal", "12tet":
+		return dsp.EqualTemperament{}, nil
+	case "just":
+		tonic, err := parseTonic(param)
+		if err != nil {
+			return nil, err
+		}
+		return dsp.NewJustIntonation(tonic), nil
+	case "pythagorean":
+		tonic, err := parseTonic(param)
+		if err != nil {
+			return nil, err
+		}
+		return dsp.NewPythagorean(tonic), nil
+	case "meantone":
+		tonic, err := parseTonic(param)
+		if err != nil {
+			return nil, err
+		}
+		return dsp.NewQuarterCommaMeantone(tonic), nil
+	case "scala":
+		return dsp.LoadScala(param)
+	default:
+		return nil, fmt.Errorf("unknown temperament %q", name)
+	}
+}
+
+// parseTonic resolves a tonic note name (e.g. "D", "F#") to a pitch
+// class (0=C .. 11=B). An empty name defaults to C.
+func parseTonic(name string) (int, error) {
+	if name == "" {
+		return 0, nil
+	}
+	for i, n := range dsp.NoteNames() {
+		if strings.EqualFold(n, name) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown tonic %q", name)
+}
+
 func main() {
 	// threshold := flag.Float64("t", 0.1, "The MPM algorithm's detection threshold [0 - 1.0]. Low values increase the sensitivity.")
 	instrumentName := flag.String("i", "guitar", "Instrument profile (guitar, bouzouki).")
+	referenceHz := flag.Float64("a", dsp.StandardTuning.ReferenceHz, "Reference pitch for A4, in Hz.")
+	temperamentFlag := flag.String("temperament", "equal",
+		"Temperament: equal, just[:tonic], pythagorean[:tonic], meantone[:tonic], or scala:<path>.")
 	flag.Parse()
 
 	profile, ok := config.Profiles[*instrumentName]
@@ -260,6 +410,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	temperament, err := parseTemperamentFlag(*temperamentFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "temperament: %v\n", err)
+		os.Exit(1)
+	}
+	tuning := dsp.Tuning{
+		ReferenceHz:   *referenceHz,
+		ReferenceMIDI: dsp.StandardTuning.ReferenceMIDI,
+		Temperament:   temperament,
+	}
+
 	audioChan := make(chan pitchMsg)
 	ai, err := audio.NewAudioInput(sampleRate)
 	if err != nil {
@@ -270,9 +431,18 @@ func main() {
 		panic(err)
 	}
 	defer ai.Stop()
-	go processAudio(ai, audioChan, profile)
 
-	model := initialModel(profile, audioChan)
+	player, err := audio.NewPlayer(sampleRate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: reference tone playback unavailable: %v\n", err)
+		player = nil
+	} else {
+		defer player.Close()
+	}
+
+	go processAudio(ai, audioChan, profile, tuning, player)
+
+	model := initialModel(profile, tuning, audioChan, player)
 
 	p := tea.NewProgram(model)
 
@@ -0,0 +1,138 @@
+package audio
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/gen2brain/malgo"
+)
+
+// DeviceInfo describes a capture or playback device a backend can see,
+// trimmed down from malgo's raw device info to what picking one requires.
+type DeviceInfo struct {
+	ID        string // hex-encoded malgo device ID; pass to InputConfig.DeviceID / OutputConfig.DeviceID
+	Name      string
+	IsDefault bool
+
+	// NativeFormats lists the formats this device supports without
+	// miniaudio needing to insert a conversion stage, queried via malgo's
+	// per-device Context.DeviceInfo (ctx.Devices alone never populates
+	// this -- it only reports basic identity).
+	NativeFormats []NativeFormat
+}
+
+// NativeFormat is one sample format/channel-count/sample-rate combination
+// a device natively supports.
+type NativeFormat struct {
+	Format     malgo.FormatType
+	Channels   uint32
+	SampleRate uint32
+}
+
+// Backend selects the platform audio API malgo should use. The zero
+// value (BackendDefault) lets malgo fall back to its own platform
+// priority list instead of a single named backend.
+type Backend int
+
+const (
+	BackendDefault Backend = iota
+	BackendWASAPI
+	BackendALSA
+	BackendPulseAudio
+	BackendCoreAudio
+	BackendJack
+)
+
+func (b Backend) malgoBackends() []malgo.Backend {
+	switch b {
+	case BackendWASAPI:
+		return []malgo.Backend{malgo.BackendWasapi}
+	case BackendALSA:
+		return []malgo.Backend{malgo.BackendAlsa}
+	case BackendPulseAudio:
+		return []malgo.Backend{malgo.BackendPulseaudio}
+	case BackendCoreAudio:
+		return []malgo.Backend{malgo.BackendCoreaudio}
+	case BackendJack:
+		return []malgo.Backend{malgo.BackendJack}
+	default:
+		return nil // let malgo try its platform-default priority list
+	}
+}
+
+// EnumerateCaptureDevices lists the capture devices visible to malgo's
+// default backend priority list.
+func EnumerateCaptureDevices() ([]DeviceInfo, error) {
+	return enumerateDevices(malgo.Capture)
+}
+
+// EnumeratePlaybackDevices lists the playback devices visible to malgo's
+// default backend priority list.
+func EnumeratePlaybackDevices() ([]DeviceInfo, error) {
+	return enumerateDevices(malgo.Playback)
+}
+
+func enumerateDevices(kind malgo.DeviceType) ([]DeviceInfo, error) {
+	ctx, err := malgo.InitContext(nil, malgo.ContextConfig{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer ctx.Uninit()
+
+	raw, err := ctx.Devices(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]DeviceInfo, len(raw))
+	for i, d := range raw {
+		detailed, err := ctx.DeviceInfo(kind, d.ID, malgo.Shared)
+		if err != nil {
+			// Basic identity is still useful even if a device refuses to
+			// report its native formats (e.g. it was unplugged between
+			// the two calls); don't fail enumeration over it.
+			detailed = d
+		}
+
+		formats := make([]NativeFormat, detailed.FormatCount)
+		for j, f := range detailed.Formats[:detailed.FormatCount] {
+			formats[j] = NativeFormat{
+				Format:     f.Format,
+				Channels:   f.Channels,
+				SampleRate: f.SampleRate,
+			}
+		}
+
+		infos[i] = DeviceInfo{
+			ID:            d.ID.String(),
+			Name:          d.Name(),
+			IsDefault:     d.IsDefault != 0,
+			NativeFormats: formats,
+		}
+	}
+	return infos, nil
+}
+
+func parseDeviceID(hexID string) (malgo.DeviceID, error) {
+	var id malgo.DeviceID
+	decoded, err := hex.DecodeString(hexID)
+	if err != nil {
+		return id, fmt.Errorf("invalid device id %q: %w", hexID, err)
+	}
+	copy(id[:], decoded)
+	return id, nil
+}
+
+func channelCountOrDefault(channels uint32) uint32 {
+	if channels == 0 {
+		return 1
+	}
+	return channels
+}
+
+func formatOrDefault(format malgo.FormatType) malgo.FormatType {
+	if format == malgo.FormatUnknown {
+		return malgo.FormatF32
+	}
+	return format
+}
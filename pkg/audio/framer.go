@@ -0,0 +1,119 @@
+package audio
+
+import "sync/atomic"
+
+// WindowFunc tapers a frame before Framer hands it off, to reduce
+// spectral leakage ahead of FFT/autocorrelation analysis. Anything
+// satisfying dsp/filter.Filter qualifies (e.g. filter.NewHannWindow).
+type WindowFunc interface {
+	Process(in []float64) []float64
+}
+
+// Framer pulls fixed-size, optionally overlapping windows out of a
+// RingBuffer and delivers them to a caller-owned channel. It runs in its
+// own goroutine, woken by Notify, so the realtime capture callback
+// feeding the ring never has to wait on a slow consumer.
+type Framer struct {
+	ring       *RingBuffer
+	windowSize int
+	hopSize    int
+	window     WindowFunc
+
+	notify chan struct{}
+	stop   chan struct{}
+
+	underruns atomic.Uint64
+}
+
+// NewFramer creates a Framer emitting windowSize-sample frames every
+// hopSize samples (hopSize < windowSize overlaps consecutive windows)
+// out of ring. window tapers each frame before it's emitted; nil applies
+// none.
+func NewFramer(ring *RingBuffer, windowSize, hopSize int, window WindowFunc) *Framer {
+	return &Framer{
+		ring:       ring,
+		windowSize: windowSize,
+		hopSize:    hopSize,
+		window:     window,
+		notify:     make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+	}
+}
+
+// Notify wakes Run to check the ring for new data. The ring's writer
+// should call this (it never blocks) after every Write.
+func (f *Framer) Notify() {
+	select {
+	case f.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run drains windows out of the ring and sends them to out until Stop is
+// called. It's meant to be launched in its own goroutine; out's lifetime
+// is the caller's responsibility, so Run never closes it.
+func (f *Framer) Run(out chan<- []float32) {
+	for {
+		select {
+		case <-f.stop:
+			return
+		case <-f.notify:
+		}
+
+		produced := 0
+		for f.ring.Fill() >= f.windowSize {
+			raw := make([]float32, f.windowSize)
+			f.ring.Peek(raw)
+
+			frame := raw
+			if f.window != nil {
+				frame = applyWindow(f.window, raw)
+			}
+
+			select {
+			case out <- frame:
+			case <-f.stop:
+				return
+			}
+
+			f.ring.Advance(f.hopSize)
+			produced++
+		}
+
+		if produced == 0 {
+			f.underruns.Add(1)
+		}
+	}
+}
+
+// Stop halts Run.
+func (f *Framer) Stop() {
+	close(f.stop)
+}
+
+// Underruns reports how many times Run woke up to find less than a full
+// window buffered.
+func (f *Framer) Underruns() uint64 {
+	return f.underruns.Load()
+}
+
+// RingFillLevel reports how many samples are currently buffered ahead of
+// Run, for diagnosing backpressure.
+func (f *Framer) RingFillLevel() int {
+	return f.ring.Fill()
+}
+
+func applyWindow(w WindowFunc, in []float32) []float32 {
+	f64 := make([]float64, len(in))
+	for i, v := range in {
+		f64[i] = float64(v)
+	}
+
+	tapered := w.Process(f64)
+
+	out := make([]float32, len(tapered))
+	for i, v := range tapered {
+		out[i] = float32(v)
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package audio
+
+import (
+	"testing"
+	"time"
+)
+
+func recvFrame(t *testing.T, out <-chan []float32) []float32 {
+	t.Helper()
+	select {
+	case frame := <-out:
+		return frame
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a frame from Framer")
+		return nil
+	}
+}
+
+func TestFramer_EmitsOverlappingWindows(t *testing.T) {
+	ring := NewRingBuffer(64)
+	framer := NewFramer(ring, 4, 2, nil)
+
+	out := make(chan []float32, 8)
+	go framer.Run(out)
+	defer framer.Stop()
+
+	ring.Write([]float32{1, 2, 3, 4, 5, 6})
+	framer.Notify()
+
+	first := recvFrame(t, out)
+	if first[0] != 1 || first[3] != 4 {
+		t.Errorf("expected the first window to be [1 2 3 4], got %v", first)
+	}
+
+	second := recvFrame(t, out)
+	if second[0] != 3 || second[3] != 6 {
+		t.Errorf("expected the second (hop=2) window to be [3 4 5 6], got %v", second)
+	}
+}
+
+func TestFramer_AppliesWindowFunc(t *testing.T) {
+	ring := NewRingBuffer(16)
+	framer := NewFramer(ring, 4, 4, scaleWindow{factor: 2})
+
+	out := make(chan []float32, 4)
+	go framer.Run(out)
+	defer framer.Stop()
+
+	ring.Write([]float32{1, 1, 1, 1})
+	framer.Notify()
+
+	frame := recvFrame(t, out)
+	for i, v := range frame {
+		if v != 2 {
+			t.Errorf("sample %d: expected the window func to double the input to 2, got %v", i, v)
+		}
+	}
+}
+
+type scaleWindow struct{ factor float64 }
+
+func (s scaleWindow) Process(in []float64) []float64 {
+	out := make([]float64, len(in))
+	for i, v := range in {
+		out[i] = v * s.factor
+	}
+	return out
+}
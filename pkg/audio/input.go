@@ -1,53 +1,173 @@
 package audio
 
 import (
+	"runtime"
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/gen2brain/malgo"
 )
 
+// defaultWindowSize is the frame size InputConfig falls back to when
+// WindowSize is unset -- large enough for the NSDF-based pitch detector
+// to resolve low guitar/bouzouki notes.
+const defaultWindowSize = 4096
+
+// InputConfig configures a capture device in detail. NewAudioInput is a
+// convenience wrapper over NewAudioInputWithConfig for the common case
+// (mono float32 on the system's default device); reach for
+// NewAudioInputWithConfig directly to pick a specific device, backend,
+// channel count, or buffer size.
+type InputConfig struct {
+	DeviceID     string // hex ID from EnumerateCaptureDevices; empty picks the default device
+	SampleRate   uint32
+	ChannelCount uint32           // 0 defaults to 1 (mono)
+	Format       malgo.FormatType // malgo.FormatUnknown (the zero value) defaults to FormatF32
+	BufferFrames uint32           // 0 lets malgo choose its own period size
+	Backend      Backend          // zero value lets malgo try its platform-default priority list
+
+	WindowSize uint32     // samples per frame on Frames; 0 defaults to defaultWindowSize
+	HopSize    uint32     // samples between frame starts; 0 defaults to WindowSize (no overlap)
+	Window     WindowFunc // tapers each frame before it's emitted; nil applies none
+}
+
+// AudioInput captures audio from a malgo device. The capture callback
+// only memcpys into a RingBuffer and never blocks; a Framer goroutine
+// drains it into fixed-size, optionally overlapping frames on Frames, so
+// a slow consumer applies backpressure to itself rather than to the
+// realtime audio thread. Frames arrive interleaved across Channels()
+// channels; for multi-channel captures, run them through
+// dsp.DeinterleaveFloat32 before analysis.
 type AudioInput struct {
-	ctx    *malgo.AllocatedContext
-	device *malgo.Device
+	ctx        *malgo.AllocatedContext
+	device     *malgo.Device
+	channels   uint32
+	sampleRate uint32
+
+	ring   *RingBuffer
+	framer *Framer
+
+	droppedFrames atomic.Uint64
+
 	Frames chan []float32
 }
 
+// NewAudioInput opens the default capture device in mono float32 at
+// sampleRate.
 func NewAudioInput(sampleRate uint32) (*AudioInput, error) {
+	return NewAudioInputWithConfig(InputConfig{
+		SampleRate:   sampleRate,
+		ChannelCount: 1,
+		Format:       malgo.FormatF32,
+	})
+}
+
+// NewAudioInputWithConfig opens a capture device per cfg.
+func NewAudioInputWithConfig(cfg InputConfig) (*AudioInput, error) {
 	ai := &AudioInput{
 		Frames: make(chan []float32, 8),
 	}
 
-	ctx, err := malgo.InitContext(nil, malgo.ContextConfig{}, nil)
-	if err != nil {
+	if err := ai.open(cfg); err != nil {
 		return nil, err
 	}
-	ai.ctx = ctx
+
+	return ai, nil
+}
+
+func (ai *AudioInput) open(cfg InputConfig) error {
+	ai.channels = channelCountOrDefault(cfg.ChannelCount)
+	ai.sampleRate = cfg.SampleRate
+
+	windowSize := int(cfg.WindowSize)
+	if windowSize == 0 {
+		windowSize = defaultWindowSize
+	}
+	hopSize := int(cfg.HopSize)
+	if hopSize == 0 {
+		hopSize = windowSize
+	}
+
+	ai.ring = NewRingBuffer(windowSize * 4)
+	ai.framer = NewFramer(ai.ring, windowSize, hopSize, cfg.Window)
+
+	ctx, err := malgo.InitContext(cfg.Backend.malgoBackends(), malgo.ContextConfig{}, nil)
+	if err != nil {
+		return err
+	}
 
 	deviceConfig := malgo.DefaultDeviceConfig(malgo.Capture)
-	deviceConfig.Capture.Format = malgo.FormatF32
-	deviceConfig.Capture.Channels = 1
-	deviceConfig.SampleRate = sampleRate
+	deviceConfig.Capture.Format = formatOrDefault(cfg.Format)
+	deviceConfig.Capture.Channels = ai.channels
+	deviceConfig.SampleRate = cfg.SampleRate
+	if cfg.BufferFrames > 0 {
+		deviceConfig.PeriodSizeInFrames = cfg.BufferFrames
+	}
+	if cfg.DeviceID != "" {
+		id, err := parseDeviceID(cfg.DeviceID)
+		if err != nil {
+			ctx.Uninit()
+			return err
+		}
+		deviceConfig.Capture.DeviceID = id.Pointer()
+	}
 
 	callbacks := malgo.DeviceCallbacks{
 		Data: func(_, inputSamples []byte, frameCount uint32) {
 			// convert bytes to float32 using unsafe slice
 			if len(inputSamples) > 0 {
-				samplesF32 := unsafe.Slice((*float32)(unsafe.Pointer(&inputSamples[0])), frameCount)
-				// make a copy to avoid data race
-				buf := make([]float32, frameCount)
-				copy(buf, samplesF32)
-				ai.Frames <- buf
+				samplesF32 := unsafe.Slice((*float32)(unsafe.Pointer(&inputSamples[0])), frameCount*ai.channels)
+				dropped := ai.ring.Write(samplesF32)
+				runtime.KeepAlive(inputSamples) // keep the backing buffer alive through the unsafe.Slice read above
+				if dropped > 0 {
+					ai.droppedFrames.Add(uint64(dropped))
+				}
+				ai.framer.Notify()
 			}
 		},
 	}
 
 	dev, err := malgo.InitDevice(ctx.Context, deviceConfig, callbacks)
 	if err != nil {
-		return nil, err
+		ctx.Uninit()
+		return err
 	}
+
+	ai.ctx = ctx
 	ai.device = dev
 
-	return ai, nil
+	go ai.framer.Run(ai.Frames)
+
+	return nil
+}
+
+// Channels reports how many channels each []float32 on Frames is
+// interleaved across.
+func (ai *AudioInput) Channels() uint32 {
+	return ai.channels
+}
+
+// SampleRate reports the rate frames on Frames were captured at.
+func (ai *AudioInput) SampleRate() uint32 {
+	return ai.sampleRate
+}
+
+// DroppedFrames reports how many captured samples were discarded because
+// the ring buffer filled up faster than the Framer could drain it.
+func (ai *AudioInput) DroppedFrames() uint64 {
+	return ai.droppedFrames.Load()
+}
+
+// Underruns reports how many times the Framer woke up to find less than
+// a full window buffered.
+func (ai *AudioInput) Underruns() uint64 {
+	return ai.framer.Underruns()
+}
+
+// RingFillLevel reports how many samples are currently buffered ahead of
+// the Framer, for diagnosing backpressure.
+func (ai *AudioInput) RingFillLevel() int {
+	return ai.framer.RingFillLevel()
 }
 
 func (ai *AudioInput) Start() error {
@@ -55,8 +175,39 @@ func (ai *AudioInput) Start() error {
 }
 
 func (ai *AudioInput) Stop() {
-	ai.device.Stop()
-	ai.device.Uninit()
-	ai.ctx.Uninit()
+	ai.teardown()
 	close(ai.Frames)
 }
+
+// Reconfigure stops and uninits the current device and Framer and starts
+// new ones per cfg, without closing or replacing Frames -- the existing
+// consumer goroutine keeps reading from the same channel throughout.
+func (ai *AudioInput) Reconfigure(cfg InputConfig) error {
+	ai.teardown()
+
+	if err := ai.open(cfg); err != nil {
+		return err
+	}
+
+	return ai.device.Start()
+}
+
+// teardown stops and uninits the current device/context/Framer, then nils
+// ai.device/ai.ctx so that if the caller's subsequent open (in Reconfigure)
+// fails before reassigning them, a later Stop sees nil and skips them
+// instead of double-Uninit'ing already-freed malgo objects.
+func (ai *AudioInput) teardown() {
+	if ai.device != nil {
+		ai.device.Stop()
+		ai.device.Uninit()
+		ai.device = nil
+	}
+	if ai.ctx != nil {
+		ai.ctx.Uninit()
+		ai.ctx = nil
+	}
+	if ai.framer != nil {
+		ai.framer.Stop()
+		ai.framer = nil
+	}
+}
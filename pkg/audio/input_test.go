@@ -0,0 +1,29 @@
+package audio
+
+import "testing"
+
+// TestAudioInput_ReconfigureFailureDoesNotDoubleFree forces open to fail
+// partway through Reconfigure (an invalid DeviceID) and asserts a
+// subsequent Stop doesn't try to tear down the already-torn-down device
+// and context a second time.
+func TestAudioInput_ReconfigureFailureDoesNotDoubleFree(t *testing.T) {
+	ai, err := NewAudioInput(48000)
+	if err != nil {
+		t.Skipf("no capture device available in this environment: %v", err)
+	}
+
+	if err := ai.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	err = ai.Reconfigure(InputConfig{
+		SampleRate:   48000,
+		ChannelCount: 1,
+		DeviceID:     "not valid hex",
+	})
+	if err == nil {
+		t.Fatal("expected Reconfigure to fail on an invalid DeviceID")
+	}
+
+	ai.Stop() // must not panic or double-Uninit the old device/context
+}
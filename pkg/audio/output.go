@@ -0,0 +1,164 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+
+	"github.com/gen2brain/malgo"
+)
+
+// OutputConfig configures a playback device in detail, mirroring
+// InputConfig on the capture side.
+type OutputConfig struct {
+	DeviceID     string // hex ID from EnumeratePlaybackDevices; empty picks the default device
+	SampleRate   uint32
+	ChannelCount uint32           // 0 defaults to 1 (mono)
+	Format       malgo.FormatType // malgo.FormatUnknown (the zero value) defaults to FormatF32
+	BufferFrames uint32           // 0 lets malgo choose its own period size
+	Backend      Backend          // zero value lets malgo try its platform-default priority list
+}
+
+// AudioOutput is AudioInput's playback-side counterpart: push interleaved
+// float32 samples into Write and they drain out to the device as the
+// backend calls for more, silence filling any gap.
+type AudioOutput struct {
+	ctx      *malgo.AllocatedContext
+	device   *malgo.Device
+	channels uint32
+
+	mu  sync.Mutex
+	buf []float32
+}
+
+// NewAudioOutput opens the default playback device in mono float32 at
+// sampleRate.
+func NewAudioOutput(sampleRate uint32) (*AudioOutput, error) {
+	return NewAudioOutputWithConfig(OutputConfig{
+		SampleRate:   sampleRate,
+		ChannelCount: 1,
+		Format:       malgo.FormatF32,
+	})
+}
+
+// NewAudioOutputWithConfig opens a playback device per cfg.
+func NewAudioOutputWithConfig(cfg OutputConfig) (*AudioOutput, error) {
+	ao := &AudioOutput{}
+
+	if err := ao.open(cfg); err != nil {
+		return nil, err
+	}
+
+	return ao, nil
+}
+
+func (ao *AudioOutput) open(cfg OutputConfig) error {
+	ao.channels = channelCountOrDefault(cfg.ChannelCount)
+
+	ctx, err := malgo.InitContext(cfg.Backend.malgoBackends(), malgo.ContextConfig{}, nil)
+	if err != nil {
+		return err
+	}
+
+	deviceConfig := malgo.DefaultDeviceConfig(malgo.Playback)
+	deviceConfig.Playback.Format = formatOrDefault(cfg.Format)
+	deviceConfig.Playback.Channels = ao.channels
+	deviceConfig.SampleRate = cfg.SampleRate
+	if cfg.BufferFrames > 0 {
+		deviceConfig.PeriodSizeInFrames = cfg.BufferFrames
+	}
+	if cfg.DeviceID != "" {
+		id, err := parseDeviceID(cfg.DeviceID)
+		if err != nil {
+			ctx.Uninit()
+			return err
+		}
+		deviceConfig.Playback.DeviceID = id.Pointer()
+	}
+
+	callbacks := malgo.DeviceCallbacks{
+		Data: func(output, _ []byte, frameCount uint32) {
+			ao.render(output, frameCount)
+		},
+	}
+
+	dev, err := malgo.InitDevice(ctx.Context, deviceConfig, callbacks)
+	if err != nil {
+		ctx.Uninit()
+		return err
+	}
+
+	ao.ctx = ctx
+	ao.device = dev
+	return nil
+}
+
+// Write enqueues interleaved samples to be drained out to the device; it
+// does not block waiting for playback to catch up.
+func (ao *AudioOutput) Write(samples []float32) {
+	ao.mu.Lock()
+	ao.buf = append(ao.buf, samples...)
+	ao.mu.Unlock()
+}
+
+func (ao *AudioOutput) render(output []byte, frameCount uint32) {
+	ao.mu.Lock()
+	defer ao.mu.Unlock()
+
+	want := int(frameCount) * int(ao.channels)
+	n := want
+	if n > len(ao.buf) {
+		n = len(ao.buf)
+	}
+
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint32(output[i*4:], math.Float32bits(ao.buf[i]))
+	}
+	ao.buf = ao.buf[n:]
+}
+
+// Channels reports how many channels Write expects its samples
+// interleaved across.
+func (ao *AudioOutput) Channels() uint32 {
+	return ao.channels
+}
+
+func (ao *AudioOutput) Start() error {
+	return ao.device.Start()
+}
+
+func (ao *AudioOutput) Stop() {
+	ao.teardown()
+}
+
+// Reconfigure stops and uninits the current device and starts a new one
+// per cfg, discarding any samples still buffered for the old config.
+func (ao *AudioOutput) Reconfigure(cfg OutputConfig) error {
+	ao.teardown()
+
+	ao.mu.Lock()
+	ao.buf = nil
+	ao.mu.Unlock()
+
+	if err := ao.open(cfg); err != nil {
+		return err
+	}
+
+	return ao.device.Start()
+}
+
+// teardown stops and uninits the current device/context, then nils
+// ao.device/ao.ctx so that if the caller's subsequent open (in
+// Reconfigure) fails before reassigning them, a later Stop sees nil and
+// skips them instead of double-Uninit'ing already-freed malgo objects.
+func (ao *AudioOutput) teardown() {
+	if ao.device != nil {
+		ao.device.Stop()
+		ao.device.Uninit()
+		ao.device = nil
+	}
+	if ao.ctx != nil {
+		ao.ctx.Uninit()
+		ao.ctx = nil
+	}
+}
@@ -0,0 +1,142 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gen2brain/malgo"
+
+	"github.com/jakeChal/gotune/pkg/dsp"
+)
+
+// Player drives a reference tone out through the same malgo backend
+// AudioInput captures from, so users can tune by ear against a generated
+// pitch while still watching the meter react to the microphone.
+type Player struct {
+	ctx        *malgo.AllocatedContext
+	device     *malgo.Device
+	sampleRate uint32
+
+	mu  sync.Mutex
+	buf []float32
+	pos int
+
+	playing atomic.Bool
+}
+
+// NewPlayer opens a playback device at sampleRate.
+func NewPlayer(sampleRate uint32) (*Player, error) {
+	p := &Player{sampleRate: sampleRate}
+
+	ctx, err := malgo.InitContext(nil, malgo.ContextConfig{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	p.ctx = ctx
+
+	deviceConfig := malgo.DefaultDeviceConfig(malgo.Playback)
+	deviceConfig.Playback.Format = malgo.FormatF32
+	deviceConfig.Playback.Channels = 1
+	deviceConfig.SampleRate = sampleRate
+
+	callbacks := malgo.DeviceCallbacks{
+		Data: func(output, _ []byte, frameCount uint32) {
+			p.render(output, frameCount)
+		},
+	}
+
+	dev, err := malgo.InitDevice(ctx.Context, deviceConfig, callbacks)
+	if err != nil {
+		ctx.Uninit()
+		return nil, err
+	}
+	p.device = dev
+
+	return p, nil
+}
+
+// Play starts (or retargets) a continuous reference tone at freq Hz,
+// built from dsp.GenerateSineWave with harmonics extra overtones mixed in
+// at decreasing amplitude for a more pluck-like timbre than a pure sine;
+// 0 harmonics is a pure tone.
+func (p *Player) Play(freq float64, harmonics int) error {
+	buf := renderTone(freq, harmonics, p.sampleRate)
+
+	p.mu.Lock()
+	p.buf = buf
+	p.pos = 0
+	p.mu.Unlock()
+
+	if p.playing.Load() {
+		return nil
+	}
+	p.playing.Store(true)
+	return p.device.Start()
+}
+
+// Stop silences the reference tone.
+func (p *Player) Stop() error {
+	if !p.playing.Load() {
+		return nil
+	}
+	p.playing.Store(false)
+	return p.device.Stop()
+}
+
+// IsPlaying reports whether a reference tone is currently sounding, so
+// callers can gate pitch detection to avoid feeding the tone back into
+// DetectPitch.
+func (p *Player) IsPlaying() bool {
+	return p.playing.Load()
+}
+
+// Close releases the playback device. The Player must not be used
+// afterwards.
+func (p *Player) Close() {
+	p.device.Uninit()
+	p.ctx.Uninit()
+}
+
+func (p *Player) render(output []byte, frameCount uint32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.buf) == 0 {
+		return
+	}
+
+	for i := 0; i < int(frameCount); i++ {
+		binary.LittleEndian.PutUint32(output[i*4:], math.Float32bits(p.buf[p.pos]))
+		p.pos = (p.pos + 1) % len(p.buf)
+	}
+}
+
+// renderTone builds one seamlessly-loopable span of freq (and its
+// harmonics) using dsp.GenerateSineWave, so the output callback only has
+// to read the buffer around in a circle rather than synthesize per-sample.
+func renderTone(freq float64, harmonics int, sampleRate uint32) []float32 {
+	periods := math.Max(1, math.Round(freq*0.05)) // ~50ms, always a whole number of cycles
+	duration := periods / freq
+
+	var mix []float64
+	var totalAmp float64
+	for h := 0; h <= harmonics; h++ {
+		amp := 1.0 / float64(h+1)
+		wave := dsp.GenerateSineWave(freq*float64(h+1), duration, int(sampleRate))
+		if mix == nil {
+			mix = make([]float64, len(wave))
+		}
+		for i, s := range wave {
+			mix[i] += s * amp
+		}
+		totalAmp += amp
+	}
+
+	out := make([]float32, len(mix))
+	for i, s := range mix {
+		out[i] = float32(s / totalAmp * 0.3) // headroom so harmonics don't clip
+	}
+	return out
+}
@@ -0,0 +1,176 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Reader parses the RIFF/WAVE files produced by Writer: an int16 or
+// float32 PCM stream with an arbitrary sample rate and channel count. Samples are
+// downmixed to mono as they are read so callers can feed them straight
+// into the same pitch-detection pipeline used for live capture.
+type Reader struct {
+	file       *os.File
+	SampleRate uint32
+	Channels   uint16
+	format     SampleFormat
+	dataSize   uint32
+	read       uint32
+}
+
+// NewReader opens filename and parses its RIFF header, positioning the
+// file at the start of the "data" chunk.
+func NewReader(filename string) (*Reader, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Reader{file: f}
+	if err := r.readHeader(); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Reader) readHeader() error {
+	var riffID [4]byte
+	if err := binary.Read(r.file, binary.LittleEndian, &riffID); err != nil {
+		return err
+	}
+	if string(riffID[:]) != "RIFF" {
+		return fmt.Errorf("audio: not a RIFF file")
+	}
+	if _, err := r.file.Seek(4, io.SeekCurrent); err != nil { // skip fileSize
+		return err
+	}
+	var waveID [4]byte
+	if err := binary.Read(r.file, binary.LittleEndian, &waveID); err != nil {
+		return err
+	}
+	if string(waveID[:]) != "WAVE" {
+		return fmt.Errorf("audio: not a WAVE file")
+	}
+
+	var gotFmt bool
+	for {
+		var chunkID [4]byte
+		if err := binary.Read(r.file, binary.LittleEndian, &chunkID); err != nil {
+			return fmt.Errorf("audio: missing data chunk: %w", err)
+		}
+		var chunkSize uint32
+		if err := binary.Read(r.file, binary.LittleEndian, &chunkSize); err != nil {
+			return err
+		}
+
+		switch string(chunkID[:]) {
+		case "fmt ":
+			var format struct {
+				AudioFormat   uint16
+				NumChannels   uint16
+				SampleRate    uint32
+				ByteRate      uint32
+				BlockAlign    uint16
+				BitsPerSample uint16
+			}
+			if err := binary.Read(r.file, binary.LittleEndian, &format); err != nil {
+				return err
+			}
+			switch {
+			case format.AudioFormat == 1 && format.BitsPerSample == 16:
+				r.format = SampleFormatInt16
+			case format.AudioFormat == 3 && format.BitsPerSample == 32:
+				r.format = SampleFormatFloat32
+			default:
+				return fmt.Errorf("audio: unsupported format (only int16 or float32 PCM is supported)")
+			}
+			r.Channels = format.NumChannels
+			r.SampleRate = format.SampleRate
+			gotFmt = true
+
+			if extra := int64(chunkSize) - 16; extra > 0 {
+				if _, err := r.file.Seek(extra, io.SeekCurrent); err != nil {
+					return err
+				}
+			}
+		case "data":
+			if !gotFmt {
+				return fmt.Errorf("audio: data chunk before fmt chunk")
+			}
+			r.dataSize = chunkSize
+			return nil
+		default:
+			if _, err := r.file.Seek(int64(chunkSize), io.SeekCurrent); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ReadFrame reads up to n mono samples, downmixing multi-channel files by
+// averaging their channels. It returns io.EOF once the data chunk is
+// exhausted, possibly alongside a final short frame.
+func (r *Reader) ReadFrame(n int) ([]float32, error) {
+	if r.Channels == 0 {
+		return nil, fmt.Errorf("audio: reader not initialized")
+	}
+
+	frame := make([]float32, 0, n)
+
+	for len(frame) < n {
+		if r.read >= r.dataSize {
+			if len(frame) == 0 {
+				return nil, io.EOF
+			}
+			return frame, io.EOF
+		}
+
+		mono, err := r.readSample()
+		if err != nil {
+			if len(frame) == 0 {
+				return nil, io.EOF
+			}
+			return frame, io.EOF
+		}
+		frame = append(frame, mono)
+	}
+
+	return frame, nil
+}
+
+// readSample reads one multi-channel sample and downmixes it to mono.
+func (r *Reader) readSample() (float32, error) {
+	if r.format == SampleFormatFloat32 {
+		raw := make([]float32, r.Channels)
+		if err := binary.Read(r.file, binary.LittleEndian, &raw); err != nil {
+			return 0, err
+		}
+		r.read += uint32(len(raw)) * 4
+
+		var sum float32
+		for _, s := range raw {
+			sum += s
+		}
+		return sum / float32(len(raw)), nil
+	}
+
+	raw := make([]int16, r.Channels)
+	if err := binary.Read(r.file, binary.LittleEndian, &raw); err != nil {
+		return 0, err
+	}
+	r.read += uint32(len(raw)) * 2
+
+	var sum int32
+	for _, s := range raw {
+		sum += int32(s)
+	}
+	return float32(sum) / float32(len(raw)) / 32768.0, nil
+}
+
+func (r *Reader) Close() error {
+	return r.file.Close()
+}
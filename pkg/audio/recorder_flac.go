@@ -0,0 +1,90 @@
+//go:build enable_codec_libflac
+
+package audio
+
+import (
+	"os"
+
+	"github.com/mewkiz/flac"
+	"github.com/mewkiz/flac/frame"
+	"github.com/mewkiz/flac/meta"
+)
+
+func init() {
+	newFlacTapeFile = newFlacWriter
+}
+
+const flacBitsPerSample = 16
+
+// flacWriter encodes captured frames as FLAC via mewkiz/flac, mirroring
+// flac2wav/wav2flac from the external flacgo docs: one StreamInfo header
+// up front, one encoded frame per WriteFrame call, Close flushes and
+// finalizes the stream.
+type flacWriter struct {
+	file     *os.File
+	enc      *flac.Encoder
+	channels uint32
+}
+
+func newFlacWriter(path string, sampleRate, channels uint32) (tapeFile, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &meta.StreamInfo{
+		SampleRate:    sampleRate,
+		NChannels:     uint8(channels),
+		BitsPerSample: flacBitsPerSample,
+	}
+	enc, err := flac.NewEncoder(f, info)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &flacWriter{file: f, enc: enc, channels: channels}, nil
+}
+
+// WriteFrame encodes samples (interleaved across w.channels) as a single
+// FLAC frame of fixed-size subframes at flacBitsPerSample.
+func (w *flacWriter) WriteFrame(samples []float32) error {
+	scale := float32(int32(1) << (flacBitsPerSample - 1))
+	frameCount := len(samples) / int(w.channels)
+
+	subframes := make([]*frame.Subframe, w.channels)
+	for ch := range subframes {
+		sub := &frame.Subframe{
+			SubHeader: frame.SubHeader{
+				Pred: frame.PredVerbatim,
+			},
+			Samples:  make([]int32, frameCount),
+			NSamples: frameCount,
+		}
+		for i := 0; i < frameCount; i++ {
+			sub.Samples[i] = int32(samples[i*int(w.channels)+ch] * scale)
+		}
+		subframes[ch] = sub
+	}
+
+	fr := &frame.Frame{
+		Header: frame.Header{
+			HasFixedBlockSize: true,
+			BlockSize:         uint16(frameCount),
+			SampleRate:        0, // inherited from the stream's StreamInfo
+			Channels:          frame.Channels(w.channels - 1),
+			BitsPerSample:     flacBitsPerSample,
+		},
+		Subframes: subframes,
+	}
+
+	return w.enc.WriteFrame(fr)
+}
+
+func (w *flacWriter) Close() error {
+	if err := w.enc.Close(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
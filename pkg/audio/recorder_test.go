@@ -0,0 +1,77 @@
+package audio
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecorder_RollsOnMaxBytes(t *testing.T) {
+	dir := t.TempDir()
+
+	rec, err := NewRecorder(RecorderConfig{
+		Dir:        dir,
+		Prefix:     "take",
+		SampleRate: 48000,
+		Channels:   1,
+		Format:     SampleFormatInt16,
+		MaxBytes:   8, // 4 int16 samples
+	})
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	// First write stays under MaxBytes, so no roll yet.
+	if err := rec.WriteFrame([]float32{0.1, 0.2}); err != nil {
+		t.Fatalf("WriteFrame: %v", err)
+	}
+	// This write brings written bytes up to MaxBytes, triggering a roll
+	// before the *next* WriteFrame call.
+	if err := rec.WriteFrame([]float32{0.3, 0.4}); err != nil {
+		t.Fatalf("WriteFrame: %v", err)
+	}
+	if err := rec.WriteFrame([]float32{0.5}); err != nil {
+		t.Fatalf("WriteFrame: %v", err)
+	}
+
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	for _, name := range []string{"take-000.wav", "take-001.wav"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to exist: %v", name, err)
+		}
+	}
+}
+
+func TestRecorder_NoRotationWritesOneFile(t *testing.T) {
+	dir := t.TempDir()
+
+	rec, err := NewRecorder(RecorderConfig{
+		Dir:        dir,
+		Prefix:     "take",
+		SampleRate: 48000,
+		Channels:   1,
+	})
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := rec.WriteFrame([]float32{0.1, 0.2, 0.3}); err != nil {
+			t.Fatalf("WriteFrame: %v", err)
+		}
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected exactly one rolled file, got %d", len(entries))
+	}
+}
@@ -0,0 +1,91 @@
+package audio
+
+import "sync/atomic"
+
+// RingBuffer is a lock-free single-producer, single-consumer float32 ring
+// buffer. The malgo capture callback is the sole writer and must never
+// block; Framer's goroutine is the sole reader. Both sides only ever
+// touch atomics, never a mutex.
+type RingBuffer struct {
+	buf  []float32
+	mask uint64
+
+	write atomic.Uint64
+	read  atomic.Uint64
+}
+
+// NewRingBuffer allocates a ring sized to the next power of two >= size,
+// so index wrapping is a cheap bitmask instead of a modulo.
+func NewRingBuffer(size int) *RingBuffer {
+	capacity := nextPowerOfTwo(size)
+	return &RingBuffer{
+		buf:  make([]float32, capacity),
+		mask: uint64(capacity - 1),
+	}
+}
+
+// Write copies as many samples from in as there's room for. Once the
+// ring is full it drops the tail of in (the newest samples that didn't
+// fit) rather than overwrite unread data, and reports how many were
+// dropped that way.
+func (r *RingBuffer) Write(in []float32) (dropped int) {
+	w := r.write.Load()
+	rd := r.read.Load()
+	free := uint64(len(r.buf)) - (w - rd)
+
+	n := uint64(len(in))
+	if n > free {
+		dropped = int(n - free)
+		in = in[:free]
+		n = free
+	}
+
+	for i, v := range in {
+		r.buf[(w+uint64(i))&r.mask] = v
+	}
+	r.write.Store(w + n)
+
+	return dropped
+}
+
+// Peek copies up to len(out) of the oldest unread samples into out
+// without removing them from the ring, returning how many were copied.
+func (r *RingBuffer) Peek(out []float32) int {
+	w := r.write.Load()
+	rd := r.read.Load()
+	available := w - rd
+
+	n := uint64(len(out))
+	if n > available {
+		n = available
+	}
+	for i := uint64(0); i < n; i++ {
+		out[i] = r.buf[(rd+i)&r.mask]
+	}
+
+	return int(n)
+}
+
+// Advance discards n samples from the front of the ring, after the
+// caller has consumed them via Peek.
+func (r *RingBuffer) Advance(n int) {
+	r.read.Add(uint64(n))
+}
+
+// Fill reports how many samples are currently buffered.
+func (r *RingBuffer) Fill() int {
+	return int(r.write.Load() - r.read.Load())
+}
+
+// Capacity reports the ring's total size in samples.
+func (r *RingBuffer) Capacity() int {
+	return len(r.buf)
+}
+
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
@@ -0,0 +1,54 @@
+package audio
+
+import "testing"
+
+func TestRingBuffer_WriteAndPeekRoundTrip(t *testing.T) {
+	r := NewRingBuffer(8)
+
+	in := []float32{1, 2, 3, 4}
+	if dropped := r.Write(in); dropped != 0 {
+		t.Fatalf("expected no samples dropped, got %d", dropped)
+	}
+
+	out := make([]float32, 4)
+	if n := r.Peek(out); n != 4 {
+		t.Fatalf("expected to peek 4 samples, got %d", n)
+	}
+	for i, v := range in {
+		if out[i] != v {
+			t.Errorf("sample %d: expected %v, got %v", i, v, out[i])
+		}
+	}
+
+	if r.Fill() != 4 {
+		t.Errorf("expected fill of 4 before Advance, got %d", r.Fill())
+	}
+}
+
+func TestRingBuffer_AdvanceDrainsFill(t *testing.T) {
+	r := NewRingBuffer(8)
+	r.Write([]float32{1, 2, 3, 4})
+
+	r.Advance(2)
+	if r.Fill() != 2 {
+		t.Errorf("expected fill of 2 after advancing past 2 samples, got %d", r.Fill())
+	}
+
+	out := make([]float32, 2)
+	r.Peek(out)
+	if out[0] != 3 || out[1] != 4 {
+		t.Errorf("expected to peek the remaining samples [3 4], got %v", out)
+	}
+}
+
+func TestRingBuffer_DropsTailWhenFull(t *testing.T) {
+	r := NewRingBuffer(4) // rounds up to capacity 4
+
+	dropped := r.Write([]float32{1, 2, 3, 4, 5, 6})
+	if dropped != 2 {
+		t.Fatalf("expected 2 samples dropped once the ring filled, got %d", dropped)
+	}
+	if r.Fill() != 4 {
+		t.Errorf("expected fill to cap at the ring's capacity, got %d", r.Fill())
+	}
+}
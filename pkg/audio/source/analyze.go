@@ -0,0 +1,70 @@
+package source
+
+import "github.com/jakeChal/gotune/pkg/dsp"
+
+const (
+	analyzeBufferSize       = 4096
+	analyzeThreshold        = 0.1
+	analyzeSilenceThreshold = 0.001
+)
+
+// AnalyzeFile decodes path via Open and replays it through the same
+// PitchTracker used for live capture, so a recorded take can be
+// regression-tested or shared without a capture device. The returned
+// channel is closed once the file is exhausted or Read returns an error
+// other than io.EOF; callers that need to distinguish the two should
+// inspect path themselves (e.g. os.Stat it first) rather than block on
+// the channel indefinitely.
+func AnalyzeFile(path string) (<-chan dsp.PitchResult, error) {
+	src, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan dsp.PitchResult)
+	go func() {
+		defer close(out)
+		defer src.Close()
+
+		tracker := dsp.NewPitchTracker(src.SampleRate(), analyzeBufferSize, analyzeThreshold, analyzeSilenceThreshold)
+		channels := src.Channels()
+		buf := make([]float32, analyzeBufferSize)
+
+		for {
+			n, readErr := src.Read(buf)
+			if n > 0 {
+				for _, result := range tracker.Push(downmixToMono(buf[:n], channels)) {
+					out <- result
+				}
+			}
+			if readErr != nil {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// downmixToMono averages an interleaved multi-channel buffer down to one
+// float64 sample per frame, the same convention wav.go and flac.go use
+// for sources that don't already force mono. Sources like ogg and opus
+// report their true channel count and hand back raw interleaved
+// samples, so AnalyzeFile can't assume buf is already mono. A trailing
+// partial frame (len(buf) not a multiple of channels) is dropped.
+func downmixToMono(buf []float32, channels int) []float64 {
+	if channels <= 1 {
+		return dsp.Float32ToFloat64(buf)
+	}
+
+	frames := len(buf) / channels
+	out := make([]float64, frames)
+	for i := 0; i < frames; i++ {
+		var sum float32
+		for c := 0; c < channels; c++ {
+			sum += buf[i*channels+c]
+		}
+		out[i] = float64(sum / float32(channels))
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package source
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jakeChal/gotune/pkg/audio"
+	"github.com/jakeChal/gotune/pkg/dsp"
+)
+
+func TestAnalyzeFile_EmitsOneResultPerWindow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tone.wav")
+
+	const sampleRate = 48000
+	writer, err := audio.NewWriter(path, sampleRate, 1)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	// Three full analysis windows' worth of a 440 Hz tone.
+	seconds := float64(3*analyzeBufferSize) / float64(sampleRate)
+	samples := dsp.GenerateSineWave(440, seconds, sampleRate)
+	frame := make([]float32, len(samples))
+	for i, s := range samples {
+		frame[i] = float32(s)
+	}
+	if err := writer.WriteFrame(frame); err != nil {
+		t.Fatalf("WriteFrame: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	results, err := AnalyzeFile(path)
+	if err != nil {
+		t.Fatalf("AnalyzeFile: %v", err)
+	}
+
+	var count int
+	for range results {
+		count++
+	}
+
+	if count != 3 {
+		t.Errorf("expected 3 PitchResults for 3 full windows, got %d", count)
+	}
+}
+
+func TestDownmixToMono(t *testing.T) {
+	t.Run("mono passthrough", func(t *testing.T) {
+		in := []float32{0.1, 0.2, 0.3}
+		got := downmixToMono(in, 1)
+		want := dsp.Float32ToFloat64(in)
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("sample %d: got %v, want %v", i, got[i], want[i])
+			}
+		}
+	})
+
+	t.Run("averages interleaved stereo frames", func(t *testing.T) {
+		// L/R pairs: (1, -1) -> 0, (0.5, 0.5) -> 0.5.
+		got := downmixToMono([]float32{1, -1, 0.5, 0.5}, 2)
+		want := []float64{0, 0.5}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("sample %d: got %v, want %v", i, got[i], want[i])
+			}
+		}
+	})
+
+	t.Run("drops a trailing partial frame", func(t *testing.T) {
+		got := downmixToMono([]float32{1, -1, 0.5}, 2)
+		if len(got) != 1 {
+			t.Errorf("expected the dangling sample to be dropped, got %d samples", len(got))
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package source
+
+import "fmt"
+
+// Format opens a Source from a file path. Implementations register
+// themselves under a name (e.g. "wav", "flac") via Register, usually from
+// an init() in their own build-tag-gated file, so a codec that isn't
+// compiled in simply never ends up in the registry.
+type Format interface {
+	Open(path string) (Source, error)
+}
+
+var registry = map[string]Format{}
+
+// Register adds a Format under name so Open can find it by file
+// extension.
+func Register(name string, f Format) {
+	registry[name] = f
+}
+
+// Open decodes path using the Format registered for its extension.
+func Open(path string) (Source, error) {
+	ext := extOf(path)
+
+	f, ok := registry[ext]
+	if !ok {
+		return nil, fmt.Errorf("source: no decoder registered for %q files", ext)
+	}
+
+	return f.Open(path)
+}
+
+func extOf(path string) string {
+	for i := len(path) - 1; i >= 0 && path[i] != '/'; i-- {
+		if path[i] == '.' {
+			return path[i+1:]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,31 @@
+package source
+
+import (
+	"io"
+
+	"github.com/jakeChal/gotune/pkg/audio"
+)
+
+// LiveSource adapts audio.AudioInput's push-based Frames channel to the
+// pull-based Source interface, so the same pipeline that tunes from a
+// live mic can run unmodified against files and streams.
+type LiveSource struct {
+	ai *audio.AudioInput
+}
+
+// NewLiveSource wraps an already-started AudioInput as a Source.
+func NewLiveSource(ai *audio.AudioInput) *LiveSource {
+	return &LiveSource{ai: ai}
+}
+
+func (s *LiveSource) Read(buf []float32) (int, error) {
+	frame, ok := <-s.ai.Frames
+	if !ok {
+		return 0, io.EOF
+	}
+	return copy(buf, frame), nil
+}
+
+func (s *LiveSource) SampleRate() int { return int(s.ai.SampleRate()) }
+func (s *LiveSource) Channels() int   { return int(s.ai.Channels()) }
+func (s *LiveSource) Close() error    { s.ai.Stop(); return nil }
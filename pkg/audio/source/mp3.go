@@ -0,0 +1,64 @@
+//go:build !disable_format_mp3
+
+package source
+
+import (
+	"os"
+
+	"github.com/hajimehoshi/go-mp3"
+
+	"github.com/jakeChal/gotune/pkg/dsp"
+)
+
+func init() {
+	Register("mp3", mp3Format{})
+}
+
+type mp3Format struct{}
+
+func (mp3Format) Open(path string) (Source, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dec, err := mp3.NewDecoder(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &mp3Source{file: f, dec: dec}, nil
+}
+
+// mp3Source decodes go-mp3's interleaved int16 stereo output to float32
+// as it's read, downmixing to mono to match the rest of the pipeline.
+type mp3Source struct {
+	file *os.File
+	dec  *mp3.Decoder
+}
+
+func (s *mp3Source) Read(buf []float32) (int, error) {
+	raw := make([]byte, len(buf)*4) // int16 stereo: 2 channels * 2 bytes per output sample
+	n, err := s.dec.Read(raw)
+
+	frames := n / 4
+	samples := dsp.Int16ToFloat32(bytesToInt16(raw[:frames*4]))
+	for i := 0; i < frames; i++ {
+		buf[i] = (samples[i*2] + samples[i*2+1]) / 2
+	}
+
+	return frames, err
+}
+
+func (s *mp3Source) SampleRate() int { return s.dec.SampleRate() }
+func (s *mp3Source) Channels() int   { return 1 } // downmixed to mono, like audio.Reader
+func (s *mp3Source) Close() error    { return s.file.Close() }
+
+func bytesToInt16(b []byte) []int16 {
+	out := make([]int16, len(b)/2)
+	for i := range out {
+		out[i] = int16(b[i*2]) | int16(b[i*2+1])<<8
+	}
+	return out
+}
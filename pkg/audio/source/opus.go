@@ -0,0 +1,40 @@
+//go:build enable_codec_libopus
+
+package source
+
+import (
+	"os"
+
+	"github.com/hraban/opus"
+)
+
+func init() {
+	Register("opus", opusFormat{})
+}
+
+type opusFormat struct{}
+
+func (opusFormat) Open(path string) (Source, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := opus.NewStream(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &opusSource{file: f, stream: stream}, nil
+}
+
+type opusSource struct {
+	file   *os.File
+	stream *opus.Stream
+}
+
+func (s *opusSource) Read(buf []float32) (int, error) { return s.stream.Read(buf) }
+func (s *opusSource) SampleRate() int                 { return s.stream.SampleRate() }
+func (s *opusSource) Channels() int                   { return s.stream.Channels() }
+func (s *opusSource) Close() error                    { return s.file.Close() }
@@ -0,0 +1,18 @@
+// Package source abstracts decoded audio away from where it came from, so
+// the pitch-detection pipeline can run unmodified against a live
+// microphone, a WAV/MP3/FLAC/Ogg/Opus file, or anything else that can
+// produce frames.
+package source
+
+// Source yields decoded audio frames at a known sample rate and channel
+// count.
+type Source interface {
+	// Read fills buf with up to len(buf) interleaved samples and returns
+	// how many samples it wrote. It returns io.EOF once the source is exhausted,
+	// possibly alongside a final short read.
+	Read(buf []float32) (n int, err error)
+
+	SampleRate() int
+	Channels() int
+	Close() error
+}
@@ -0,0 +1,32 @@
+package source
+
+import "github.com/jakeChal/gotune/pkg/audio"
+
+func init() {
+	Register("wav", wavFormat{})
+}
+
+type wavFormat struct{}
+
+func (wavFormat) Open(path string) (Source, error) {
+	r, err := audio.NewReader(path)
+	if err != nil {
+		return nil, err
+	}
+	return &wavSource{reader: r}, nil
+}
+
+// wavSource adapts audio.Reader's ReadFrame(n) chunked API to Source's
+// fill-the-caller's-buffer convention.
+type wavSource struct {
+	reader *audio.Reader
+}
+
+func (s *wavSource) Read(buf []float32) (int, error) {
+	frame, err := s.reader.ReadFrame(len(buf))
+	return copy(buf, frame), err
+}
+
+func (s *wavSource) SampleRate() int { return int(s.reader.SampleRate) }
+func (s *wavSource) Channels() int   { return 1 } // audio.Reader downmixes to mono
+func (s *wavSource) Close() error    { return s.reader.Close() }
@@ -6,14 +6,33 @@ import (
 	"os"
 )
 
+// SampleFormat selects the PCM sample encoding Writer encodes to disk.
+type SampleFormat int
+
+const (
+	// SampleFormatInt16 stores samples as signed 16-bit PCM (WAV format
+	// tag 1). It is Writer's original, and still default, behaviour.
+	SampleFormatInt16 SampleFormat = iota
+	// SampleFormatFloat32 stores samples as IEEE 754 float32 (WAV format
+	// tag 3), avoiding the quantization int16 introduces.
+	SampleFormatFloat32
+)
+
 type Writer struct {
 	file         *os.File
 	sampleRate   uint32
 	channels     uint32
+	format       SampleFormat
 	bytesWritten uint32
 }
 
 func NewWriter(filename string, sampleRate, channels uint32) (*Writer, error) {
+	return NewWriterWithFormat(filename, sampleRate, channels, SampleFormatInt16)
+}
+
+// NewWriterWithFormat opens filename and writes a RIFF/WAVE header for a
+// PCM stream in the given SampleFormat.
+func NewWriterWithFormat(filename string, sampleRate, channels uint32, format SampleFormat) (*Writer, error) {
 	f, err := os.Create(filename)
 	if err != nil {
 		return nil, err
@@ -23,11 +42,17 @@ func NewWriter(filename string, sampleRate, channels uint32) (*Writer, error) {
 		file:       f,
 		sampleRate: sampleRate,
 		channels:   channels,
+		format:     format,
 	}
 
+	audioFormat := uint16(1) // PCM
 	bitsPerSample := uint16(16)
-	bytesPerSample := uint16(2)
-	byteRate := sampleRate * uint32(channels) * uint32(bytesPerSample)
+	if format == SampleFormatFloat32 {
+		audioFormat = 3 // IEEE float
+		bitsPerSample = 32
+	}
+	bytesPerSample := bitsPerSample / 8
+	byteRate := sampleRate * channels * uint32(bytesPerSample)
 	blockAlign := uint16(channels) * bytesPerSample
 
 	write := func(data any) error {
@@ -54,7 +79,7 @@ func NewWriter(filename string, sampleRate, channels uint32) (*Writer, error) {
 		return nil, err
 	}
 
-	// Write fmt chunk (24 bytes): format info (PCM, sample rate, channels, bit depth)
+	// Write fmt chunk (24 bytes): format info (PCM/float, sample rate, channels, bit depth)
 	if err := write("fmt "); err != nil {
 		f.Close()
 		return nil, err
@@ -63,7 +88,7 @@ func NewWriter(filename string, sampleRate, channels uint32) (*Writer, error) {
 		f.Close()
 		return nil, err
 	}
-	if err := write(uint16(1)); err != nil { // PCM format
+	if err := write(audioFormat); err != nil {
 		f.Close()
 		return nil, err
 	}
@@ -110,6 +135,14 @@ func (w *Writer) WriteFrame(samples []float32) error {
 			sample = -1.0
 		}
 
+		if w.format == SampleFormatFloat32 {
+			if err := binary.Write(w.file, binary.LittleEndian, sample); err != nil {
+				return err
+			}
+			w.bytesWritten += 4
+			continue
+		}
+
 		// Convert to int16 PCM
 		pcmValue := int16(sample * 32767)
 
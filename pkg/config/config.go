@@ -1,9 +1,51 @@
 package config
 
+import "math"
+
+// stringMatchCentsLimit is how far, in cents, a detected pitch may drift
+// from a configured string's target before MatchString gives up and
+// callers should fall back to plain chromatic note snapping.
+const stringMatchCentsLimit = 200.0
+
+// StringSpec describes one string, or course, of a stringed instrument:
+// its conventional label and target pitch.
+type StringSpec struct {
+	Name     string
+	TargetHz float64
+	MIDI     int // MIDI note number of TargetHz under 12-TET, 0 if unset
+}
+
 type InstrumentProfile struct {
 	Name    string
 	MinFreq float64
 	MaxFreq float64
+	Strings []StringSpec
+}
+
+// MatchString returns the configured string whose target pitch is
+// closest to freq, and how many cents freq is off from it. If no string
+// is within stringMatchCentsLimit cents (or the profile has no strings
+// defined), it returns a zero-value StringSpec -- callers should fall
+// back to chromatic note snapping in that case.
+func (p InstrumentProfile) MatchString(freq float64) (StringSpec, float64) {
+	if freq <= 0 || len(p.Strings) == 0 {
+		return StringSpec{}, 0
+	}
+
+	var best StringSpec
+	bestCents := math.Inf(1)
+	for _, s := range p.Strings {
+		cents := 1200 * math.Log2(freq/s.TargetHz)
+		if math.Abs(cents) < math.Abs(bestCents) {
+			best, bestCents = s, cents
+		}
+	}
+
+	if math.Abs(bestCents) > stringMatchCentsLimit {
+		return StringSpec{}, 0
+	}
+
+	return best, bestCents
 }
 
 var Profiles = map[string]InstrumentProfile{
@@ -11,11 +53,26 @@ var Profiles = map[string]InstrumentProfile{
 		Name:    "Guitar (Standard)",
 		MinFreq: 75.0,   // Slightly below E2
 		MaxFreq: 1400.0, // Slightly above E6
+		Strings: []StringSpec{
+			{Name: "6th String (E2)", TargetHz: 82.41, MIDI: 40},
+			{Name: "5th String (A2)", TargetHz: 110.00, MIDI: 45},
+			{Name: "4th String (D3)", TargetHz: 146.83, MIDI: 50},
+			{Name: "3rd String (G3)", TargetHz: 196.00, MIDI: 55},
+			{Name: "2nd String (B3)", TargetHz: 246.94, MIDI: 59},
+			{Name: "1st String (E4)", TargetHz: 329.63, MIDI: 64},
+		},
 	},
 	"bouzouki": {
 		Name:    "Bouzouki (6 or 8 string)",
 		MinFreq: 110.0, // Slightly below C3
 		MaxFreq: 360.0, // Slightly above F4
+		// Tetrachordo (4-course) C-F-A-D tuning.
+		Strings: []StringSpec{
+			{Name: "4th Course (C3)", TargetHz: 130.81, MIDI: 48},
+			{Name: "3rd Course (F3)", TargetHz: 174.61, MIDI: 53},
+			{Name: "2nd Course (A3)", TargetHz: 220.00, MIDI: 57},
+			{Name: "1st Course (D4)", TargetHz: 293.66, MIDI: 62},
+		},
 	},
 }
 
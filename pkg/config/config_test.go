@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+func TestMatchString(t *testing.T) {
+	profile := Profiles["guitar"]
+
+	spec, cents := profile.MatchString(82.41)
+	if spec.Name != "6th String (E2)" {
+		t.Fatalf("expected to match the low E string, got %q", spec.Name)
+	}
+	if cents < -0.01 || cents > 0.01 {
+		t.Errorf("expected ~0 cents for an exact match, got %v", cents)
+	}
+}
+
+func TestMatchString_FallsBackOutsideRange(t *testing.T) {
+	profile := Profiles["guitar"]
+
+	// 95 Hz sits ~246 cents above E2 and ~254 cents below A2, outside
+	// the ±200-cent match window of every string -- this must NOT be
+	// reported as a match against either.
+	spec, _ := profile.MatchString(95.0) // well past the E2 window, and not close to A2 either
+	if spec.Name != "" {
+		t.Errorf("expected no string match far outside the window, got %q", spec.Name)
+	}
+}
+
+func TestMatchString_NoStringsDefined(t *testing.T) {
+	profile := InstrumentProfile{Name: "no-strings", MinFreq: 50, MaxFreq: 500}
+
+	spec, _ := profile.MatchString(220)
+	if spec.Name != "" {
+		t.Errorf("expected no match when the profile defines no strings, got %q", spec.Name)
+	}
+}
@@ -0,0 +1,46 @@
+package filter
+
+import "math"
+
+// Bandpass is a 2nd-order Butterworth bandpass biquad (RBJ cookbook
+// constant-0dB-peak-gain form), used to constrain incoming audio to an
+// InstrumentProfile's MinFreq/MaxFreq range before pitch detection.
+type Bandpass struct {
+	b0, b1, b2, a1, a2 float64
+	x1, x2, y1, y2     float64
+}
+
+// NewBandpass builds a Butterworth bandpass covering [minHz, maxHz] at
+// the given sampleRate. A narrower range (e.g. bouzouki's 110-360 Hz)
+// yields a tighter, higher-Q filter than a wider one (e.g. guitar's
+// 75-1400 Hz).
+func NewBandpass(minHz, maxHz, sampleRate float64) *Bandpass {
+	centerHz := math.Sqrt(minHz * maxHz)
+	bandwidth := maxHz - minHz
+	q := centerHz / bandwidth
+
+	w0 := 2 * math.Pi * centerHz / sampleRate
+	alpha := math.Sin(w0) / (2 * q)
+	cosw0 := math.Cos(w0)
+
+	a0 := 1 + alpha
+
+	return &Bandpass{
+		b0: alpha / a0,
+		b1: 0,
+		b2: -alpha / a0,
+		a1: (-2 * cosw0) / a0,
+		a2: (1 - alpha) / a0,
+	}
+}
+
+func (b *Bandpass) Process(in []float64) []float64 {
+	out := make([]float64, len(in))
+	for i, x := range in {
+		y := b.b0*x + b.b1*b.x1 + b.b2*b.x2 - b.a1*b.y1 - b.a2*b.y2
+		out[i] = y
+		b.x2, b.x1 = b.x1, x
+		b.y2, b.y1 = b.y1, y
+	}
+	return out
+}
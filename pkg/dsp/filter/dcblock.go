@@ -0,0 +1,28 @@
+package filter
+
+// DCBlocker is a one-pole highpass filter (y[n] = x[n] - x[n-1] +
+// R*y[n-1]) that removes DC offset and sub-audio rumble before it can
+// bias the NSDF's energy normalization.
+type DCBlocker struct {
+	r       float64
+	prevIn  float64
+	prevOut float64
+}
+
+// NewDCBlocker returns a DCBlocker with pole R=0.995, which at 48 kHz
+// puts the -3 dB cutoff near 38 Hz, well below the lowest string gotune
+// tunes (E2, ~82 Hz).
+func NewDCBlocker() *DCBlocker {
+	return &DCBlocker{r: 0.995}
+}
+
+func (d *DCBlocker) Process(in []float64) []float64 {
+	out := make([]float64, len(in))
+	for i, x := range in {
+		y := x - d.prevIn + d.r*d.prevOut
+		out[i] = y
+		d.prevIn = x
+		d.prevOut = y
+	}
+	return out
+}
@@ -0,0 +1,31 @@
+// Package filter provides a small, composable signal-processing chain
+// that sits between audio capture and dsp.DetectPitch: DC blocking,
+// band-limiting, noise gating, windowing and resampling, each as an
+// independent, unit-testable stage.
+package filter
+
+// Filter transforms a buffer of audio samples. Implementations may be
+// stateful (carrying filter history across calls to support streaming
+// input) but must not retain or mutate the input slice.
+type Filter interface {
+	Process(in []float64) []float64
+}
+
+// Chain composes Filters, feeding the output of each into the next. A
+// Chain is itself a Filter, so chains can be nested.
+type Chain struct {
+	filters []Filter
+}
+
+// NewChain builds a Chain that applies filters in order.
+func NewChain(filters ...Filter) *Chain {
+	return &Chain{filters: filters}
+}
+
+func (c *Chain) Process(in []float64) []float64 {
+	out := in
+	for _, f := range c.filters {
+		out = f.Process(out)
+	}
+	return out
+}
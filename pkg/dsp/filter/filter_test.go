@@ -0,0 +1,170 @@
+package filter
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func sineWave(freq, duration float64, sampleRate int) []float64 {
+	n := int(duration * float64(sampleRate))
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = math.Sin(2 * math.Pi * freq * float64(i) / float64(sampleRate))
+	}
+	return out
+}
+
+func rms(buf []float64) float64 {
+	var sum float64
+	for _, x := range buf {
+		sum += x * x
+	}
+	return math.Sqrt(sum / float64(len(buf)))
+}
+
+func TestDCBlocker_RemovesOffset(t *testing.T) {
+	sampleRate := 48000
+	signal := sineWave(220, 0.1, sampleRate)
+	for i := range signal {
+		signal[i] += 0.5 // inject a large DC offset
+	}
+
+	blocker := NewDCBlocker()
+	out := blocker.Process(signal)
+
+	var mean float64
+	// skip the filter's settling period at the start
+	tail := out[len(out)/2:]
+	for _, x := range tail {
+		mean += x
+	}
+	mean /= float64(len(tail))
+
+	if math.Abs(mean) > 0.05 {
+		t.Errorf("expected DC offset to be removed, mean residual = %v", mean)
+	}
+}
+
+func TestBandpass_AttenuatesOutOfBand(t *testing.T) {
+	sampleRate := 48000
+	inBand := sineWave(200, 0.2, sampleRate)     // inside 110-360 Hz bouzouki range
+	outOfBand := sineWave(2000, 0.2, sampleRate) // well above range
+
+	bp := NewBandpass(110, 360, float64(sampleRate))
+	inBandOut := bp.Process(inBand)
+
+	bp2 := NewBandpass(110, 360, float64(sampleRate))
+	outOfBandOut := bp2.Process(outOfBand)
+
+	if rms(outOfBandOut) >= rms(inBandOut) {
+		t.Errorf("expected out-of-band signal to be attenuated more than in-band: in-band rms=%v, out-of-band rms=%v",
+			rms(inBandOut), rms(outOfBandOut))
+	}
+}
+
+func TestRMSGate(t *testing.T) {
+	gate := NewRMSGate(0.01)
+
+	quiet := make([]float64, 1024)
+	r := rand.New(rand.NewSource(1))
+	for i := range quiet {
+		quiet[i] = (r.Float64() - 0.5) * 0.001
+	}
+	out := gate.Process(quiet)
+	if rms(out) != 0 {
+		t.Errorf("expected quiet buffer to be gated to zero, got rms=%v", rms(out))
+	}
+
+	loud := sineWave(440, 0.02, 48000)
+	out = gate.Process(loud)
+	if rms(out) == 0 {
+		t.Error("expected loud buffer to pass through the gate unchanged")
+	}
+}
+
+func TestHannWindow_TapersEdgesToZero(t *testing.T) {
+	n := 256
+	in := make([]float64, n)
+	for i := range in {
+		in[i] = 1.0
+	}
+
+	win := NewHannWindow(n)
+	out := win.Process(in)
+
+	if out[0] > 1e-9 || out[n-1] > 1e-9 {
+		t.Errorf("expected Hann window to taper to ~0 at the edges, got %v, %v", out[0], out[n-1])
+	}
+	if out[n/2] < 0.9 {
+		t.Errorf("expected Hann window to be near 1 at the center, got %v", out[n/2])
+	}
+}
+
+func TestHammingWindow_TapersEdgesWithoutReachingZero(t *testing.T) {
+	n := 256
+	in := make([]float64, n)
+	for i := range in {
+		in[i] = 1.0
+	}
+
+	win := NewHammingWindow(n)
+	out := win.Process(in)
+
+	if out[0] < 0.05 || out[0] > 0.1 {
+		t.Errorf("expected Hamming window's edge to be ~0.08, got %v", out[0])
+	}
+	if out[n/2] < 0.9 {
+		t.Errorf("expected Hamming window to be near 1 at the center, got %v", out[n/2])
+	}
+}
+
+func TestBlackmanHarrisWindow_TapersEdgesToZero(t *testing.T) {
+	n := 256
+	in := make([]float64, n)
+	for i := range in {
+		in[i] = 1.0
+	}
+
+	win := NewBlackmanHarrisWindow(n)
+	out := win.Process(in)
+
+	if out[0] > 1e-3 || out[n-1] > 1e-3 {
+		t.Errorf("expected Blackman-Harris window to taper to ~0 at the edges, got %v, %v", out[0], out[n-1])
+	}
+	if out[n/2] < 0.9 {
+		t.Errorf("expected Blackman-Harris window to be near 1 at the center, got %v", out[n/2])
+	}
+}
+
+func TestChain_PreservesLengthAndPassesLoudSignal(t *testing.T) {
+	signal := sineWave(440, 0.02, 48000)
+	for i := range signal {
+		signal[i] += 0.3
+	}
+
+	chain := NewChain(NewDCBlocker(), NewRMSGate(0.001))
+	out := chain.Process(signal)
+
+	if len(out) != len(signal) {
+		t.Fatalf("expected chain to preserve buffer length, got %d want %d", len(out), len(signal))
+	}
+	if rms(out) == 0 {
+		t.Error("expected a loud signal to survive the chain")
+	}
+}
+
+func TestResampler_ProducesExpectedSampleCount(t *testing.T) {
+	inRate, outRate := 48000, 22050
+	signal := sineWave(220, 1.0, inRate)
+
+	resampler := NewResampler(inRate, outRate, 16)
+	out := resampler.Process(signal)
+
+	want := len(signal) * outRate / inRate
+	// polyphase history means output trails input slightly; allow slack
+	// for the filter's settling delay.
+	if diff := want - len(out); diff < 0 || diff > 32 {
+		t.Errorf("expected ~%d output samples, got %d", want, len(out))
+	}
+}
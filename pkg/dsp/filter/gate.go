@@ -0,0 +1,35 @@
+package filter
+
+import "math"
+
+// RMSGate silences a buffer whose RMS energy falls below Threshold. It
+// replaces the hard silenceThreshold constant that used to live directly
+// in cmd/tuner's audio loop, making the cutoff configurable per filter
+// chain instead.
+type RMSGate struct {
+	Threshold float64
+}
+
+// NewRMSGate returns a gate that mutes any buffer whose RMS energy is
+// below threshold.
+func NewRMSGate(threshold float64) *RMSGate {
+	return &RMSGate{Threshold: threshold}
+}
+
+func (g *RMSGate) Process(in []float64) []float64 {
+	if len(in) == 0 {
+		return in
+	}
+
+	var sum float64
+	for _, x := range in {
+		sum += x * x
+	}
+	rms := math.Sqrt(sum / float64(len(in)))
+
+	if rms >= g.Threshold {
+		return in
+	}
+
+	return make([]float64, len(in))
+}
@@ -0,0 +1,29 @@
+package filter
+
+import "math"
+
+// HannWindow applies a Hann window to each buffer it processes, tapering
+// the edges to reduce spectral leakage ahead of FFT-based analysis.
+type HannWindow struct {
+	coeffs []float64
+}
+
+// NewHannWindow precomputes coefficients for buffers of length n. Process
+// only produces a correctly-shaped window for inputs of that exact
+// length; shorter or longer buffers are tapered against a truncated or
+// repeated copy of the coefficients.
+func NewHannWindow(n int) *HannWindow {
+	coeffs := make([]float64, n)
+	for i := range coeffs {
+		coeffs[i] = 0.5 * (1 - math.Cos(2*math.Pi*float64(i)/float64(n-1)))
+	}
+	return &HannWindow{coeffs: coeffs}
+}
+
+func (h *HannWindow) Process(in []float64) []float64 {
+	out := make([]float64, len(in))
+	for i, x := range in {
+		out[i] = x * h.coeffs[i%len(h.coeffs)]
+	}
+	return out
+}
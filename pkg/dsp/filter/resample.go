@@ -0,0 +1,117 @@
+package filter
+
+import "math"
+
+// Resampler converts sample rate using a polyphase FIR. With L =
+// outRate/gcd and M = inRate/gcd, each output sample is produced by
+// dotting one of L precomputed "phases" of a shared lowpass prototype
+// filter against the input history -- the standard way to fold
+// upsample-by-L, lowpass and downsample-by-M into a single pass without
+// ever materializing the zero-stuffed intermediate signal. This lets
+// gotune capture at 48 kHz but analyse at 22.05 kHz, halving NSDF cost.
+type Resampler struct {
+	l, m   int
+	phases [][]float64 // phases[p][k] are the taps applied to input[i-k] to produce phase p
+
+	buf      []float64 // buffered input samples not yet fully consumed
+	consumed int       // count of input samples permanently dropped from the front of buf
+	nextOut  int       // count of output samples produced so far
+}
+
+// NewResampler builds a polyphase resampler converting from inRate to
+// outRate. tapsPerPhase controls the prototype lowpass filter's length
+// per phase; 16 is a reasonable default for instrument-range audio.
+func NewResampler(inRate, outRate, tapsPerPhase int) *Resampler {
+	l, m := reduceRatio(outRate, inRate)
+
+	cutoff := 1.0 / math.Max(float64(l), float64(m))
+	numTaps := tapsPerPhase * l
+	proto := make([]float64, numTaps)
+	mid := float64(numTaps-1) / 2
+	for i := range proto {
+		x := float64(i) - mid
+		sinc := 1.0
+		if x != 0 {
+			sinc = math.Sin(math.Pi*cutoff*x) / (math.Pi * cutoff * x)
+		}
+		// Hamming window
+		window := 0.54 - 0.46*math.Cos(2*math.Pi*float64(i)/float64(numTaps-1))
+		proto[i] = cutoff * sinc * window
+	}
+
+	phases := make([][]float64, l)
+	for p := 0; p < l; p++ {
+		for i := p; i < numTaps; i += l {
+			phases[p] = append(phases[p], proto[i])
+		}
+	}
+
+	return &Resampler{l: l, m: m, phases: phases}
+}
+
+func (r *Resampler) Process(in []float64) []float64 {
+	r.buf = append(r.buf, in...)
+
+	var out []float64
+	for {
+		u := r.nextOut * r.m
+		i := u / r.l
+		p := u % r.l
+
+		idx := i - r.consumed
+		if idx >= len(r.buf) {
+			break // not enough input buffered yet to produce this output sample
+		}
+
+		taps := r.phases[p]
+		var sum float64
+		for k, tap := range taps {
+			j := idx - k
+			if j >= 0 {
+				sum += tap * r.buf[j]
+			}
+		}
+
+		out = append(out, sum)
+		r.nextOut++
+	}
+
+	r.trim()
+	return out
+}
+
+// trim drops input samples that no future output sample can reference,
+// so buf doesn't grow without bound across a long capture session.
+func (r *Resampler) trim() {
+	maxTaps := 0
+	for _, p := range r.phases {
+		if len(p) > maxTaps {
+			maxTaps = len(p)
+		}
+	}
+
+	nextU := r.nextOut * r.m
+	keepFrom := nextU/r.l - maxTaps
+	if keepFrom <= r.consumed {
+		return
+	}
+
+	drop := keepFrom - r.consumed
+	if drop > len(r.buf) {
+		drop = len(r.buf)
+	}
+	r.buf = r.buf[drop:]
+	r.consumed += drop
+}
+
+func reduceRatio(a, b int) (int, int) {
+	g := gcd(a, b)
+	return a / g, b / g
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,64 @@
+package filter
+
+import "math"
+
+// HammingWindow applies a Hamming window to each buffer it processes.
+// Like HannWindow it tapers the edges ahead of FFT-based analysis, but
+// doesn't taper all the way to zero, trading a bit of edge leakage for a
+// narrower main lobe.
+type HammingWindow struct {
+	coeffs []float64
+}
+
+// NewHammingWindow precomputes coefficients for buffers of length n. See
+// HannWindow's Process doc for how mismatched buffer lengths are handled.
+func NewHammingWindow(n int) *HammingWindow {
+	coeffs := make([]float64, n)
+	for i := range coeffs {
+		coeffs[i] = 0.54 - 0.46*math.Cos(2*math.Pi*float64(i)/float64(n-1))
+	}
+	return &HammingWindow{coeffs: coeffs}
+}
+
+func (h *HammingWindow) Process(in []float64) []float64 {
+	out := make([]float64, len(in))
+	for i, x := range in {
+		out[i] = x * h.coeffs[i%len(h.coeffs)]
+	}
+	return out
+}
+
+// BlackmanHarrisWindow applies a 4-term Blackman-Harris window, which
+// trades a wider main lobe than Hann/Hamming for much deeper sidelobe
+// suppression -- useful when a strong out-of-band signal would otherwise
+// leak into the band being analysed.
+type BlackmanHarrisWindow struct {
+	coeffs []float64
+}
+
+// NewBlackmanHarrisWindow precomputes coefficients for buffers of length
+// n. See HannWindow's Process doc for how mismatched buffer lengths are
+// handled.
+func NewBlackmanHarrisWindow(n int) *BlackmanHarrisWindow {
+	const (
+		a0 = 0.35875
+		a1 = 0.48829
+		a2 = 0.14128
+		a3 = 0.01168
+	)
+
+	coeffs := make([]float64, n)
+	for i := range coeffs {
+		x := 2 * math.Pi * float64(i) / float64(n-1)
+		coeffs[i] = a0 - a1*math.Cos(x) + a2*math.Cos(2*x) - a3*math.Cos(3*x)
+	}
+	return &BlackmanHarrisWindow{coeffs: coeffs}
+}
+
+func (b *BlackmanHarrisWindow) Process(in []float64) []float64 {
+	out := make([]float64, len(in))
+	for i, x := range in {
+		out[i] = x * b.coeffs[i%len(b.coeffs)]
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+package dsp
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/dsp/fourier"
+)
+
+const (
+	// harmonicCount is the number of harmonics (including the
+	// fundamental) scored against the magnitude spectrum.
+	harmonicCount = 8
+	// harmonicWindowCents is the half-width, in cents, of the window
+	// searched around each harmonic's expected bin.
+	harmonicWindowCents = 100.0
+	// subOctaveMargin is how much higher the sub-octave's harmonic score
+	// must be before DetectPitch accepts it over MPM's original
+	// candidate, to avoid flip-flopping on borderline cases.
+	subOctaveMargin = 1.25
+)
+
+// harmonicWindow holds the amplitude statistics gathered from the
+// ±harmonicWindowCents window around one harmonic of a candidate
+// fundamental.
+type harmonicWindow struct {
+	AmpPeak float64
+	AmpMean float64
+	AmpStd  float64
+}
+
+// harmonicScore builds the FFT magnitude spectrum of buffer and scores how
+// well its harmonic series matches candidate frequency f0: for each of the
+// first harmonicCount harmonics it locates the max-amplitude bin within a
+// ±harmonicWindowCents window centered on k*f0, refines it with parabolic
+// interpolation, and accumulates that peak's amplitude weighted by its
+// z-score against the local noise floor (AmpPeak * (AmpPeak-AmpMean)/AmpStd)
+// in the same window, so loud, clearly-above-the-noise-floor harmonics
+// count more than quiet ones. It also returns the refined peak amplitude
+// of each harmonic for PitchResult.Harmonics.
+func harmonicScore(buffer []float64, sampleRate int, f0 float64) (score float64, harmonics []float64) {
+	if f0 <= 0 {
+		return 0, nil
+	}
+
+	n := len(buffer)
+	fft := fourier.NewFFT(n)
+	coeffs := fft.Coefficients(nil, buffer)
+
+	mags := make([]float64, len(coeffs))
+	for i, c := range coeffs {
+		mags[i] = cmplx.Abs(c)
+	}
+	nyquistBin := len(mags) / 2
+
+	binHz := float64(sampleRate) / float64(n)
+	harmonics = make([]float64, harmonicCount)
+
+	for k := 1; k <= harmonicCount; k++ {
+		target := float64(k) * f0
+		if target >= float64(sampleRate)/2 {
+			break
+		}
+
+		loBin := int(math.Floor(target * math.Pow(2, -harmonicWindowCents/1200.0) / binHz))
+		hiBin := int(math.Ceil(target * math.Pow(2, harmonicWindowCents/1200.0) / binHz))
+		if loBin < 1 {
+			loBin = 1
+		}
+		if hiBin >= nyquistBin {
+			hiBin = nyquistBin - 1
+		}
+		if hiBin <= loBin {
+			continue
+		}
+
+		win, peakBin := harmonicWindowStats(mags, loBin, hiBin)
+		harmonics[k-1] = refinePeakAmplitude(mags, peakBin)
+
+		if win.AmpStd > 1e-12 {
+			score += win.AmpPeak * (win.AmpPeak - win.AmpMean) / win.AmpStd
+		}
+	}
+
+	return score, harmonics
+}
+
+// harmonicWindowStats scans mags[loBin:hiBin+1] for its peak, mean and
+// standard deviation, and returns the absolute index of the peak bin.
+func harmonicWindowStats(mags []float64, loBin, hiBin int) (harmonicWindow, int) {
+	var sum, peak float64
+	peakBin := loBin
+
+	for i := loBin; i <= hiBin; i++ {
+		sum += mags[i]
+		if mags[i] > peak {
+			peak = mags[i]
+			peakBin = i
+		}
+	}
+
+	count := float64(hiBin - loBin + 1)
+	mean := sum / count
+
+	var variance float64
+	for i := loBin; i <= hiBin; i++ {
+		d := mags[i] - mean
+		variance += d * d
+	}
+	variance /= count
+
+	return harmonicWindow{AmpPeak: peak, AmpMean: mean, AmpStd: math.Sqrt(variance)}, peakBin
+}
+
+// refinePeakAmplitude fits a parabola through bin and its neighbours (over
+// log-magnitude, the usual trick for interpolating spectral peaks) to
+// estimate the true peak amplitude rather than just the sampled bin value.
+func refinePeakAmplitude(mags []float64, bin int) float64 {
+	if bin <= 0 || bin >= len(mags)-1 || mags[bin] <= 0 {
+		return mags[bin]
+	}
+
+	alpha := math.Log(mags[bin-1] + 1e-12)
+	beta := math.Log(mags[bin] + 1e-12)
+	gamma := math.Log(mags[bin+1] + 1e-12)
+
+	denominator := alpha - 2*beta + gamma
+	if math.Abs(denominator) < 1e-10 {
+		return mags[bin]
+	}
+
+	offset := 0.5 * (alpha - gamma) / denominator
+	return math.Exp(beta - 0.25*(alpha-gamma)*offset)
+}
@@ -0,0 +1,52 @@
+package dsp
+
+import "testing"
+
+func TestDetectPitch_RejectsOctaveErrorWithDominantSecondHarmonic(t *testing.T) {
+	sampleRate := 48000
+	fundamental := 110.0
+	duration := 0.5
+
+	// A string whose second harmonic is much stronger than the
+	// fundamental: this is the classic case that makes MPM's raw NSDF
+	// peak land on 2*fundamental instead of fundamental.
+	fundamentalWave := GenerateSineWave(fundamental, duration, sampleRate)
+	secondHarmonic := GenerateSineWave(2*fundamental, duration, sampleRate)
+
+	signal := make([]float64, len(fundamentalWave))
+	for i := range signal {
+		signal[i] = 0.3*fundamentalWave[i] + 0.9*secondHarmonic[i]
+	}
+
+	result := DetectPitch(signal, sampleRate, 0.1)
+
+	if !result.HasPitch {
+		t.Fatal("expected pitch to be detected")
+	}
+	if !AlmostEqual(result.Frequency, fundamental, 1.0) {
+		t.Errorf("expected harmonic verification to settle on %.1f Hz, got %.3f", fundamental, result.Frequency)
+	}
+	if len(result.Harmonics) != harmonicCount {
+		t.Errorf("expected %d harmonic amplitudes, got %d", harmonicCount, len(result.Harmonics))
+	}
+}
+
+func TestDetectPitch_A440HasHarmonics(t *testing.T) {
+	sampleRate := 48000
+	signal := GenerateSineWave(440.0, 0.5, sampleRate)
+
+	result := DetectPitch(signal, sampleRate, 0.1)
+
+	if !result.HasPitch {
+		t.Fatal("expected pitch to be detected")
+	}
+	if !AlmostEqual(result.Frequency, 440.0, 1.0) {
+		t.Errorf("expected 440.0 Hz, got %.3f", result.Frequency)
+	}
+	if len(result.Harmonics) != harmonicCount {
+		t.Fatalf("expected %d harmonic amplitudes, got %d", harmonicCount, len(result.Harmonics))
+	}
+	if result.Harmonics[0] <= 0 {
+		t.Errorf("expected a non-zero fundamental peak amplitude, got %v", result.Harmonics[0])
+	}
+}
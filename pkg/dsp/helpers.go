@@ -9,3 +9,46 @@ func Float32ToFloat64(in []float32) []float64 {
 
 	return out
 }
+
+// Int16ToFloat32 converts signed 16-bit PCM samples, as decoded by the
+// audio/source codecs, to the [-1, 1] float32 range the rest of the
+// pipeline expects.
+func Int16ToFloat32(in []int16) []float32 {
+	out := make([]float32, len(in))
+	for i, v := range in {
+		out[i] = float32(v) / 32768.0
+	}
+
+	return out
+}
+
+// Int32ToFloat32 converts signed 32-bit PCM samples, as decoded by the
+// audio/source codecs, to the [-1, 1] float32 range the rest of the
+// pipeline expects.
+func Int32ToFloat32(in []int32) []float32 {
+	out := make([]float32, len(in))
+	for i, v := range in {
+		out[i] = float32(v) / 2147483648.0
+	}
+
+	return out
+}
+
+// DeinterleaveFloat32 splits an interleaved multi-channel buffer, as
+// captured by audio.AudioInput when Channels() > 1, into one slice per
+// channel.
+func DeinterleaveFloat32(interleaved []float32, channels int) [][]float32 {
+	frames := len(interleaved) / channels
+	out := make([][]float32, channels)
+	for c := range out {
+		out[c] = make([]float32, frames)
+	}
+
+	for i := 0; i < frames; i++ {
+		for c := 0; c < channels; c++ {
+			out[c][i] = interleaved[i*channels+c]
+		}
+	}
+
+	return out
+}
@@ -13,9 +13,10 @@ type peak struct {
 	value float64 // NSDF value at this lag
 }
 type PitchResult struct {
-	Frequency float64 // Detected frequency in Hz (0 if no pitch found)
-	Clarity   float64 // Confidence score (0.0 to 1.0)
-	HasPitch  bool    // Whether a valid pitch was detected
+	Frequency float64   // Detected frequency in Hz (0 if no pitch found)
+	Clarity   float64   // Confidence score (0.0 to 1.0)
+	HasPitch  bool      // Whether a valid pitch was detected
+	Harmonics []float64 // Refined peak amplitude of harmonics 1..8 of Frequency, nil if no pitch found
 }
 
 // Calculate the Normalized Square Difference Function (NSDF).
@@ -147,7 +148,7 @@ func autocorrelationFFT(buffer []float64, fftSize int) []float64 {
 // Algorithm steps:
 // 1. Calculate NSDF (Normalized Square Difference Function)
 // 2. Find positive peaks above threshold
-// 3. Select the highest peak (maximum clarity)
+// 3. Select the first peak that clears the threshold (MPM's "key maximum")
 // 4. Use parabolic interpolation for sub-sample accuracy
 // 5. Convert lag to frequency
 //
@@ -171,13 +172,11 @@ func DetectPitch(buffer []float64, sampleRate int, threshold float64) PitchResul
 		return PitchResult{Frequency: 0, Clarity: 0, HasPitch: false}
 	}
 
-	// Step 3: Select the highest peak (maximum clarity)
+	// Step 3: Select the first peak that clears the threshold (MPM's "key
+	// maximum") rather than the global argmax -- floating-point noise in
+	// m(tau)'s energy normalization can let a far-away lag's NSDF value
+	// creep fractionally above a closer, true-period peak's.
 	bestPeak := peaks[0]
-	for _, p := range peaks[1:] {
-		if p.value > bestPeak.value {
-			bestPeak = p
-		}
-	}
 
 	// Step 4: Refine peak location with parabolic interpolation
 	refinedLag := parabolicInterpolation(nsdf, bestPeak.index)
@@ -189,9 +188,22 @@ func DetectPitch(buffer []float64, sampleRate int, threshold float64) PitchResul
 
 	frequency := float64(sampleRate) / refinedLag
 
+	// Step 6: verify against the harmonic series to catch MPM's classic
+	// failure mode on plucked strings, where a dominant second harmonic
+	// causes the NSDF peak to land on the octave above the true
+	// fundamental. If the sub-octave scores materially better, prefer it.
+	score, harmonics := harmonicScore(buffer, sampleRate, frequency)
+	subOctave := frequency / 2
+	subScore, subHarmonics := harmonicScore(buffer, sampleRate, subOctave)
+	if subScore > score*subOctaveMargin {
+		frequency = subOctave
+		harmonics = subHarmonics
+	}
+
 	return PitchResult{
 		Frequency: frequency,
 		Clarity:   bestPeak.value,
 		HasPitch:  true,
+		Harmonics: harmonics,
 	}
 }
@@ -86,7 +86,7 @@ func TestPeakPicking(t *testing.T) {
 	nsdf[100] = 0.8
 	nsdf[500] = 0.9
 
-	peaks := PeakPicking(nsdf, 0.5)
+	peaks := peakPicking(nsdf, 0.5)
 
 	if len(peaks) != 2 {
 		t.Errorf("Expected 2 peaks, got %d", len(peaks))
@@ -8,20 +8,62 @@ import (
 var noteNames = []string{"C", "C#", "D", "D#", "E", "F",
 	"F#", "G", "G#", "A", "A#", "B"}
 
-// PitchToNote converts a frequency in Hz to musical note information.
-// Returns note name (e.g., "A4"), MIDI number, and cents offset from that note.
-func PitchToNote(frequency float64) (noteName string, midiNumber int, cents float64) {
+// NoteNames returns the 12 chromatic pitch-class names starting at C, in
+// the order used to index a Temperament's degrees and MIDI numbers mod 12.
+func NoteNames() []string {
+	return noteNames
+}
+
+// Tuning converts a frequency to musical note information relative to a
+// reference pitch and a Temperament. The zero value is not usable;
+// construct one with ReferenceHz/ReferenceMIDI set, or use StandardTuning.
+type Tuning struct {
+	ReferenceHz   float64
+	ReferenceMIDI int
+	Temperament   Temperament
+}
+
+// StandardTuning is A4 = 440 Hz under 12-tone equal temperament: the
+// tuner's historical, and still default, behaviour.
+var StandardTuning = Tuning{
+	ReferenceHz:   440.0,
+	ReferenceMIDI: 69,
+	Temperament:   EqualTemperament{},
+}
+
+// PitchToNote converts a frequency in Hz to musical note information
+// under this Tuning: note name (e.g., "A4"), MIDI number, and cents
+// offset from that note's pitch under the Tuning's Temperament.
+func (tu Tuning) PitchToNote(frequency float64) (noteName string, midiNumber int, cents float64) {
 	if frequency <= 0 {
 		return "", 0, 0
 	}
 
-	//	A4 = 440 Hz = MIDI note 69
-	midiNumberOrig := 69 + 12*math.Log2(frequency/440.0)
+	refHz := tu.ReferenceHz
+	if refHz == 0 {
+		refHz = StandardTuning.ReferenceHz
+	}
+
+	midiNumberOrig := float64(tu.ReferenceMIDI) + 12*math.Log2(frequency/refHz)
 	midiNumber = int(math.Round(midiNumberOrig))
-	cents = 100 * (midiNumberOrig - float64(midiNumber))
+	equalCents := 100 * (midiNumberOrig - float64(midiNumber))
+
+	temperament := tu.Temperament
+	if temperament == nil {
+		temperament = EqualTemperament{}
+	}
+	cents = equalCents - temperament.CentsFromEqual(midiNumber)
 
 	octave := (midiNumber / 12) - 1
-	note := noteNames[midiNumber%12]
+	note := noteNames[((midiNumber%12)+12)%12]
 	noteName = fmt.Sprintf("%s%d", note, octave)
 	return noteName, midiNumber, cents
 }
+
+// PitchToNote converts a frequency in Hz to musical note information
+// using StandardTuning (A4 = 440 Hz, 12-TET). It is kept as a
+// package-level function, rather than folded into Tuning, so existing
+// callers are unaffected by the temperament refactor.
+func PitchToNote(frequency float64) (noteName string, midiNumber int, cents float64) {
+	return StandardTuning.PitchToNote(frequency)
+}
@@ -0,0 +1,183 @@
+package dsp
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Temperament maps a MIDI note number to a deviation, in cents, from
+// that note's pitch under standard 12-tone equal temperament.
+type Temperament interface {
+	// CentsFromEqual returns how many cents the pitch class of midi sits
+	// above (or, if negative, below) the same note under 12-TET.
+	CentsFromEqual(midi int) float64
+}
+
+// EqualTemperament is standard 12-tone equal temperament: every note
+// matches itself, so this always returns 0.
+type EqualTemperament struct{}
+
+func (EqualTemperament) CentsFromEqual(midi int) float64 { return 0 }
+
+// scaleDegreeTemperament is the shared implementation behind Just
+// Intonation, Pythagorean tuning and quarter-comma Meantone: each is a
+// fixed set of cents offsets from 12-TET for the 12 scale degrees above a
+// tonic pitch class, repeating every octave.
+type scaleDegreeTemperament struct {
+	tonic int // pitch class (0=C .. 11=B) degree 0 is measured from
+	cents [12]float64
+}
+
+func (s scaleDegreeTemperament) CentsFromEqual(midi int) float64 {
+	degree := ((midi-s.tonic)%12 + 12) % 12
+	return s.cents[degree]
+}
+
+func centsFromRatios(ratios [12]float64) [12]float64 {
+	var cents [12]float64
+	for i, ratio := range ratios {
+		cents[i] = 1200*math.Log2(ratio) - float64(i)*100
+	}
+	return cents
+}
+
+// justIntonationRatios are the 5-limit just intonation ratios for the 12
+// chromatic degrees above the tonic.
+var justIntonationRatios = [12]float64{
+	1.0, 16.0 / 15, 9.0 / 8, 6.0 / 5, 5.0 / 4, 4.0 / 3,
+	45.0 / 32, 3.0 / 2, 8.0 / 5, 5.0 / 3, 9.0 / 5, 15.0 / 8,
+}
+
+// NewJustIntonation builds 5-limit just intonation with the given tonic
+// pitch class (0=C, 1=C#, ... 11=B).
+func NewJustIntonation(tonic int) Temperament {
+	return scaleDegreeTemperament{tonic: tonic, cents: centsFromRatios(justIntonationRatios)}
+}
+
+// pythagoreanRatios are the 3-limit (stacked pure fifths) ratios for the
+// 12 chromatic degrees above the tonic.
+var pythagoreanRatios = [12]float64{
+	1.0, 256.0 / 243, 9.0 / 8, 32.0 / 27, 81.0 / 64, 4.0 / 3,
+	729.0 / 512, 3.0 / 2, 128.0 / 81, 27.0 / 16, 16.0 / 9, 243.0 / 128,
+}
+
+// NewPythagorean builds 3-limit Pythagorean tuning with the given tonic
+// pitch class.
+func NewPythagorean(tonic int) Temperament {
+	return scaleDegreeTemperament{tonic: tonic, cents: centsFromRatios(pythagoreanRatios)}
+}
+
+// quarterCommaMeantoneCents are the published cents deviations from
+// 12-TET for quarter-comma meantone, built from a chain of 696.578-cent
+// fifths and octave-reduced onto a standard 12-note keyboard, with degree
+// 0 as the tonic.
+var quarterCommaMeantoneCents = [12]float64{
+	0, -24.0, -6.8, 10.3, -13.7, 3.4, -20.5, -3.4, -27.4, -10.3, 6.8, -17.1,
+}
+
+// NewQuarterCommaMeantone builds quarter-comma meantone temperament with
+// the given tonic pitch class.
+func NewQuarterCommaMeantone(tonic int) Temperament {
+	return scaleDegreeTemperament{tonic: tonic, cents: quarterCommaMeantoneCents}
+}
+
+// ScalaTemperament is a user-supplied tuning loaded from a Scala (.scl)
+// file: http://www.huygens-fokker.org/scala/scl_format.html
+type ScalaTemperament struct {
+	cents [12]float64
+}
+
+func (s *ScalaTemperament) CentsFromEqual(midi int) float64 {
+	degree := ((midi % 12) + 12) % 12
+	return s.cents[degree]
+}
+
+// LoadScala parses a 12-tone Scala .scl file into a ScalaTemperament. Each
+// non-comment, non-blank line after the description is either a note
+// count, or a scale degree expressed as a cents value ("203.91") or a
+// ratio ("3/2"); the final degree is the octave (usually "2/1" or
+// "1200.0") and is not stored, since Temperament is defined per pitch
+// class.
+func LoadScala(path string) (*ScalaTemperament, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var degrees []float64
+	numNotes := -1
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		if numNotes == -1 {
+			n, err := strconv.Atoi(strings.Fields(line)[0])
+			if err != nil {
+				continue // this was the free-text description line
+			}
+			numNotes = n
+			continue
+		}
+
+		cents, err := parseScalaDegree(strings.Fields(line)[0])
+		if err != nil {
+			return nil, fmt.Errorf("dsp: invalid Scala degree %q: %w", line, err)
+		}
+		degrees = append(degrees, cents)
+		if len(degrees) == numNotes {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if numNotes != 12 {
+		return nil, fmt.Errorf("dsp: gotune only supports 12-tone Scala scales, got %d degrees", numNotes)
+	}
+	if len(degrees) != numNotes {
+		return nil, fmt.Errorf("dsp: expected %d Scala degrees, got %d", numNotes, len(degrees))
+	}
+
+	var cents [12]float64 // degree 0, the tonic, is always 0 cents from itself
+	for i := 0; i < 11; i++ {
+		cents[i+1] = degrees[i] - float64(i+1)*100
+	}
+
+	return &ScalaTemperament{cents: cents}, nil
+}
+
+func parseScalaDegree(field string) (float64, error) {
+	if strings.Contains(field, "/") {
+		parts := strings.SplitN(field, "/", 2)
+		num, err := strconv.ParseFloat(parts[0], 64)
+		if err != nil {
+			return 0, err
+		}
+		den, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return 0, err
+		}
+		return 1200 * math.Log2(num/den), nil
+	}
+
+	if !strings.Contains(field, ".") {
+		// a bare integer is a ratio over 1, e.g. "2" means the octave 2/1
+		ratio, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return 0, err
+		}
+		return 1200 * math.Log2(ratio), nil
+	}
+
+	return strconv.ParseFloat(field, 64)
+}
@@ -0,0 +1,86 @@
+package dsp
+
+import (
+	"math"
+	"os"
+	"testing"
+)
+
+func TestTuning_DefaultMatchesPitchToNote(t *testing.T) {
+	for _, freq := range []float64{110, 220, 440, 880} {
+		wantNote, wantMIDI, wantCents := PitchToNote(freq)
+		gotNote, gotMIDI, gotCents := StandardTuning.PitchToNote(freq)
+
+		if gotNote != wantNote || gotMIDI != wantMIDI || !AlmostEqual(gotCents, wantCents, 1e-9) {
+			t.Errorf("StandardTuning diverged from PitchToNote at %v Hz: got (%s, %d, %v), want (%s, %d, %v)",
+				freq, gotNote, gotMIDI, gotCents, wantNote, wantMIDI, wantCents)
+		}
+	}
+}
+
+func TestTuning_AlternativeReferencePitch(t *testing.T) {
+	tuning := Tuning{ReferenceHz: 442, ReferenceMIDI: 69, Temperament: EqualTemperament{}}
+
+	note, midi, cents := tuning.PitchToNote(442)
+	if note != "A4" || midi != 69 || !AlmostEqual(cents, 0, 0.01) {
+		t.Errorf("expected A4 at 0 cents for a 442 Hz reference, got (%s, %d, %v)", note, midi, cents)
+	}
+
+	// The same 440 Hz that reads as dead-on under the standard tuning
+	// should now read flat relative to a 442 Hz reference.
+	_, _, centsAt440 := tuning.PitchToNote(440)
+	if centsAt440 >= 0 {
+		t.Errorf("expected 440 Hz to read flat against a 442 Hz reference, got %v cents", centsAt440)
+	}
+}
+
+func TestJustIntonation_PerfectFifthIsWideOfEqualTemperament(t *testing.T) {
+	// A just perfect fifth (3/2) above C sits about 2 cents sharp of the
+	// 12-TET fifth (700 cents); G is degree 7 above a C tonic.
+	ji := NewJustIntonation(0) // tonic = C
+	got := ji.CentsFromEqual(67)
+
+	if !AlmostEqual(got, 1200*math.Log2(1.5)-700, 0.01) {
+		t.Errorf("expected the just fifth's deviation from 12-TET, got %v cents", got)
+	}
+}
+
+func TestLoadScala_TwelveToneEqualRoundTrips(t *testing.T) {
+	scl := `! 12tet.scl
+!
+12-tone equal temperament, expressed as a Scala file
+ 12
+!
+ 100.0
+ 200.0
+ 300.0
+ 400.0
+ 500.0
+ 600.0
+ 700.0
+ 800.0
+ 900.0
+ 1000.0
+ 1100.0
+ 2/1
+`
+	f, err := os.CreateTemp(t.TempDir(), "*.scl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(scl); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	temperament, err := LoadScala(f.Name())
+	if err != nil {
+		t.Fatalf("LoadScala failed: %v", err)
+	}
+
+	for midi := 60; midi < 72; midi++ {
+		if got := temperament.CentsFromEqual(midi); !AlmostEqual(got, 0, 1e-9) {
+			t.Errorf("expected 12-TET Scala file to have 0 deviation at midi %d, got %v", midi, got)
+		}
+	}
+}
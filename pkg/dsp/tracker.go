@@ -0,0 +1,70 @@
+package dsp
+
+// Filter is satisfied by dsp/filter.Filter and dsp/filter.Chain. It's
+// declared here, rather than importing the filter package directly, so a
+// PitchTracker can run each window through an arbitrary processing
+// pipeline (band-limiting, gating, windowing, ...) without pkg/dsp
+// depending on pkg/dsp/filter.
+type Filter interface {
+	Process(in []float64) []float64
+}
+
+// PitchTracker owns the sliding analysis buffer that used to live inline
+// in the tuner's audio loop. It turns a stream of raw samples -- whether
+// they come from live capture or a decoded file -- into PitchResult
+// events, one per completed analysis window, so callers become thin
+// adapters around a single detection pipeline.
+type PitchTracker struct {
+	sampleRate       int
+	bufferSize       int
+	threshold        float64
+	silenceThreshold float64
+	filter           Filter
+	accum            []float64
+}
+
+// NewPitchTracker creates a tracker that accumulates samples until it has
+// bufferSize of them, then runs MPM pitch detection on the window at the
+// given threshold. Windows whose RMS energy is below silenceThreshold are
+// skipped rather than fed to DetectPitch.
+func NewPitchTracker(sampleRate, bufferSize int, threshold, silenceThreshold float64) *PitchTracker {
+	return &PitchTracker{
+		sampleRate:       sampleRate,
+		bufferSize:       bufferSize,
+		threshold:        threshold,
+		silenceThreshold: silenceThreshold,
+		accum:            make([]float64, 0, bufferSize),
+	}
+}
+
+// SetFilter installs a processing pipeline that every completed window is
+// run through before the silence check and pitch detection. Passing nil
+// removes it.
+func (t *PitchTracker) SetFilter(f Filter) {
+	t.filter = f
+}
+
+// Push appends newly captured samples to the tracker's sliding buffer and
+// returns one PitchResult for every analysis window completed as a
+// result, in order. Silent windows are consumed to keep the buffer
+// sliding but do not produce a result.
+func (t *PitchTracker) Push(samples []float64) []PitchResult {
+	t.accum = append(t.accum, samples...)
+
+	var results []PitchResult
+	for len(t.accum) >= t.bufferSize {
+		window := t.accum[:t.bufferSize]
+		if t.filter != nil {
+			window = t.filter.Process(window)
+		}
+
+		if CalculateRMS(window) >= t.silenceThreshold {
+			results = append(results, DetectPitch(window, t.sampleRate, t.threshold))
+		}
+
+		// Keep overflow samples for better continuity.
+		t.accum = t.accum[t.bufferSize:]
+	}
+
+	return results
+}
@@ -0,0 +1,40 @@
+package dsp
+
+import "testing"
+
+func TestPitchTracker_EmitsOneResultPerWindow(t *testing.T) {
+	sampleRate := 48000
+	bufferSize := 4096
+	signal := GenerateSineWave(440.0, 0.5, sampleRate)
+
+	tracker := NewPitchTracker(sampleRate, bufferSize, 0.1, 0.001)
+
+	var results []PitchResult
+	for i := 0; i+bufferSize <= len(signal); i += bufferSize {
+		results = append(results, tracker.Push(signal[i:i+bufferSize])...)
+	}
+
+	if len(results) == 0 {
+		t.Fatal("expected at least one pitch result")
+	}
+
+	for _, r := range results {
+		if !r.HasPitch {
+			t.Fatal("expected pitch to be detected in every window")
+		}
+		if !AlmostEqual(r.Frequency, 440.0, 1.0) {
+			t.Errorf("expected ~440 Hz, got %.3f", r.Frequency)
+		}
+	}
+}
+
+func TestPitchTracker_SkipsSilence(t *testing.T) {
+	tracker := NewPitchTracker(48000, 4096, 0.1, 0.001)
+
+	silence := make([]float64, 4096)
+	results := tracker.Push(silence)
+
+	if len(results) != 0 {
+		t.Errorf("expected silent window to be skipped, got %d results", len(results))
+	}
+}